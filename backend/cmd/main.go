@@ -3,13 +3,18 @@ package main
 import (
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"xinxun-news/internal/config"
 	"xinxun-news/internal/database"
 	"xinxun-news/internal/models"
+	"xinxun-news/internal/rewards"
 	"xinxun-news/internal/routes"
+	"xinxun-news/internal/sanitize"
 	"xinxun-news/internal/services"
+	"xinxun-news/internal/similarity"
+	"xinxun-news/internal/trash"
 
 	"gorm.io/gorm"
 )
@@ -18,6 +23,12 @@ func main() {
 	// Load configuration
 	config.LoadConfig()
 
+	// Allow-list for article HTML
+	contentPolicy := sanitize.DefaultPolicy()
+	contentPolicy.ImageHosts = config.AppConfig.ContentImageHosts
+	contentPolicy.EmbedHosts = config.AppConfig.ContentEmbedHosts
+	sanitize.SetPolicy(contentPolicy)
+
 	// Connect to database
 	database.Connect()
 
@@ -27,6 +38,9 @@ func main() {
 	// Initialize S3 service
 	services.InitS3()
 
+	// Initialize mailer (SMTP, file or log)
+	services.InitMailer()
+
 	// Auto-seed database if it's the first run
 	if len(os.Args) > 1 && os.Args[1] == "seed" {
 		seedDatabase()
@@ -41,6 +55,28 @@ func main() {
 		seedDatabase()
 	}
 
+	// Existing installs may still run the seeded admin with its default password
+	requireDefaultAdminPasswordChange()
+
+	// Articles saved before word counts were stored
+	backfillContentMetadata()
+
+	// Pay view-milestone reward bonuses in the background
+	interval, err := time.ParseDuration(config.AppConfig.RewardMilestoneInterval)
+	if err != nil || interval <= 0 {
+		log.Printf("Warning: invalid REWARD_MILESTONE_INTERVAL %q, using 10m", config.AppConfig.RewardMilestoneInterval)
+		interval = 10 * time.Minute
+	}
+	rewards.StartMilestoneWorker(interval)
+
+	// Empty the trash of articles past the retention period
+	purgeInterval, err := time.ParseDuration(config.AppConfig.TrashPurgeInterval)
+	if err != nil || purgeInterval <= 0 {
+		log.Printf("Warning: invalid TRASH_PURGE_INTERVAL %q, using 1h", config.AppConfig.TrashPurgeInterval)
+		purgeInterval = time.Hour
+	}
+	trash.StartPurgeWorker(purgeInterval)
+
 	// Setup routes
 	r := routes.SetupRoutes()
 
@@ -52,6 +88,54 @@ func main() {
 	}
 }
 
+// requireDefaultAdminPasswordChange flags the seeded admin account for a
+// password change while it still uses the default password
+func requireDefaultAdminPasswordChange() {
+	var admin models.User
+	if err := database.DB.Where("username = ?", "admin").First(&admin).Error; err != nil {
+		return
+	}
+	if admin.MustChangePassword || !services.CheckPasswordHash("admin123", admin.PasswordHash) {
+		return
+	}
+	if err := database.DB.Model(&admin).Update("must_change_password", true).Error; err != nil {
+		log.Printf("Warning: Could not flag default admin password: %v", err)
+		return
+	}
+	log.Println("Default admin password detected, password change required on next login")
+}
+
+// backfillContentMetadata computes the word count, reading time, content
+// signature and signature bands of articles stored before those columns
+// existed. UpdateColumns skips the News hooks, so the bands are written here.
+func backfillContentMetadata() {
+	var news []models.News
+	err := database.DB.Select("id", "content").
+		Where("(word_count = 0 OR reading_time = 0 OR content_signature IS NULL OR content_signature = '') AND content <> ''").
+		FindInBatches(&news, 100, func(tx *gorm.DB, batch int) error {
+			for _, item := range news {
+				text := models.PlainText(item.Content)
+				wordCount := len(strings.Fields(text))
+				signature := similarity.Signature(text)
+				if err := database.DB.Model(&models.News{}).Where("id = ?", item.ID).
+					UpdateColumns(map[string]interface{}{
+						"word_count":        wordCount,
+						"reading_time":      models.ReadingTime(wordCount),
+						"content_signature": signature,
+					}).Error; err != nil {
+					return err
+				}
+				if err := models.ReplaceSignatureBands(database.DB, item.ID, signature); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		log.Printf("Warning: Could not backfill content metadata: %v", err)
+	}
+}
+
 func seedDatabase() {
 	log.Println("Seeding database...")
 
@@ -67,6 +151,9 @@ func seedDatabase() {
 		Email:        "admin@xinxun.us",
 		PasswordHash: hashedPassword,
 		UserType:     models.UserTypeAdmin,
+		Status:       models.UserStatusActive,
+		// Default credentials are public, force a new password on first login
+		MustChangePassword: true,
 	}
 
 	if err := database.DB.FirstOrCreate(&admin, models.User{Username: "admin"}).Error; err != nil {
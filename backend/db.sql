@@ -13,12 +13,22 @@ CREATE TABLE IF NOT EXISTS users (
     name VARCHAR(255) NOT NULL,
     email VARCHAR(255) NOT NULL UNIQUE,
     password_hash VARCHAR(255) NOT NULL,
-    user_type ENUM('admin', 'publisher') DEFAULT 'admin',
+    user_type ENUM('admin', 'editor', 'publisher') DEFAULT 'admin',
     xinxun_id BIGINT UNSIGNED NULL,
     xinxun_number VARCHAR(20),
-    balance DECIMAL(15,2) DEFAULT 0.00,
+    balance DECIMAL(15,2) DEFAULT 0.00, -- running sum of balance_entries
     status VARCHAR(20) DEFAULT 'Active',
     reff_code VARCHAR(50),
+    must_change_password BOOLEAN DEFAULT FALSE,
+    email_verified_at TIMESTAMP NULL DEFAULT NULL,
+    two_factor_enabled BOOLEAN DEFAULT FALSE,
+    two_factor_secret VARCHAR(64),
+    two_factor_last_step BIGINT DEFAULT 0,
+    token_version INT UNSIGNED DEFAULT 0, -- bumped on password change to revoke older JWTs
+    display_name VARCHAR(255),
+    bio TEXT,
+    avatar_url VARCHAR(500),
+    social_links TEXT,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP NULL DEFAULT NULL,
@@ -31,7 +41,9 @@ CREATE TABLE IF NOT EXISTS users (
 CREATE TABLE IF NOT EXISTS categories (
     id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
     name VARCHAR(255) NOT NULL,
+    names TEXT,
     slug VARCHAR(255) NOT NULL UNIQUE,
+    parent_id BIGINT UNSIGNED NULL DEFAULT NULL,
     is_admin_only BOOLEAN DEFAULT FALSE,
     `order` INT DEFAULT 0,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
@@ -40,7 +52,8 @@ CREATE TABLE IF NOT EXISTS categories (
     INDEX idx_slug (slug),
     INDEX idx_deleted_at (deleted_at),
     INDEX idx_is_admin_only (is_admin_only),
-    INDEX idx_order (`order`)
+    INDEX idx_order (`order`),
+    INDEX idx_parent_id (parent_id)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 -- Add order column if not exists (for existing databases)
@@ -66,6 +79,7 @@ DEALLOCATE PREPARE alterIfNotExists;
 CREATE TABLE IF NOT EXISTS tags (
     id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
     name VARCHAR(255) NOT NULL,
+    names TEXT,
     slug VARCHAR(255) NOT NULL UNIQUE,
     `order` INT DEFAULT 0,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
@@ -100,17 +114,36 @@ CREATE TABLE IF NOT EXISTS news (
     id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
     title VARCHAR(255) NOT NULL,
     slug VARCHAR(255) NOT NULL UNIQUE,
+    language VARCHAR(10) DEFAULT 'id',
+    translation_group_id BIGINT UNSIGNED NULL DEFAULT NULL,
     content TEXT NOT NULL,
+    content_blocks MEDIUMTEXT,
     excerpt TEXT,
     thumbnail VARCHAR(500),
+    auto_excerpt BOOLEAN DEFAULT FALSE,
+    auto_thumbnail BOOLEAN DEFAULT FALSE,
     category_id BIGINT UNSIGNED NOT NULL,
     author_id BIGINT UNSIGNED NOT NULL,
     published_at TIMESTAMP NULL DEFAULT NULL,
     views INT UNSIGNED DEFAULT 0,
-    status ENUM('draft', 'published', 'pending', 'rejected') DEFAULT 'draft',
+    is_pinned BOOLEAN DEFAULT FALSE,
+    pinned_until TIMESTAMP NULL DEFAULT NULL,
+    is_featured BOOLEAN DEFAULT FALSE,
+    featured_order INT DEFAULT 0,
+    featured_until TIMESTAMP NULL DEFAULT NULL,
+    type ENUM('standard', 'live_blog') DEFAULT 'standard',
+    is_breaking BOOLEAN DEFAULT FALSE,
+    breaking_until TIMESTAMP NULL DEFAULT NULL,
+    word_count INT UNSIGNED DEFAULT 0,
+    reading_time INT UNSIGNED DEFAULT 0,
+    status ENUM('draft', 'published', 'pending', 'rejected', 'unpublished', 'archived') DEFAULT 'draft',
     reward_amount DECIMAL(15,2) DEFAULT 0.00,
     is_rewarded BOOLEAN DEFAULT FALSE,
     revision_of BIGINT UNSIGNED NULL DEFAULT NULL,
+    rejection_reason TEXT,
+    content_signature TEXT,
+    similarity_score DOUBLE DEFAULT 0,
+    similar_matches TEXT,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP NULL DEFAULT NULL,
@@ -118,8 +151,13 @@ CREATE TABLE IF NOT EXISTS news (
     INDEX idx_category_id (category_id),
     INDEX idx_author_id (author_id),
     INDEX idx_status (status),
+    INDEX idx_is_pinned (is_pinned),
+    INDEX idx_is_featured (is_featured),
+    INDEX idx_is_breaking (is_breaking),
     INDEX idx_published_at (published_at),
     INDEX idx_revision_of (revision_of),
+    INDEX idx_language (language),
+    INDEX idx_translation_group_id (translation_group_id),
     INDEX idx_deleted_at (deleted_at),
     FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE RESTRICT,
     FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE RESTRICT
@@ -136,3 +174,168 @@ CREATE TABLE IF NOT EXISTS news_tags (
     INDEX idx_tag_id (tag_id)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
+-- Slugs of merged tags, redirecting to the tag they were merged into
+CREATE TABLE IF NOT EXISTS tag_redirects (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    slug VARCHAR(255) NOT NULL UNIQUE,
+    tag_id BIGINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_tag_id (tag_id),
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Single-use tokens sent by email (password reset, email verification)
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    purpose VARCHAR(32) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_user_id (user_id),
+    INDEX idx_purpose (purpose),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Two-factor recovery codes (hashed, single use)
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    code_hash CHAR(64) NOT NULL,
+    used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_user_id (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Runtime settings managed from the admin panel
+CREATE TABLE IF NOT EXISTS settings (
+    `key` VARCHAR(100) PRIMARY KEY,
+    value TEXT,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Audit trail of administrative actions
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    actor_id BIGINT UNSIGNED NULL,
+    actor_type VARCHAR(20),
+    action VARCHAR(64) NOT NULL,
+    target_type VARCHAR(32),
+    target_id BIGINT UNSIGNED,
+    changes TEXT,
+    ip_address VARCHAR(64),
+    user_agent VARCHAR(500),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_audit_logs_actor_id (actor_id),
+    INDEX idx_audit_logs_action (action),
+    INDEX idx_audit_target (target_type, target_id),
+    INDEX idx_audit_logs_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Publisher balance ledger; users.balance is the running sum
+CREATE TABLE IF NOT EXISTS balance_entries (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    balance_after DECIMAL(15,2) NOT NULL,
+    news_id BIGINT UNSIGNED NULL,
+    reason VARCHAR(500),
+    rule TEXT,
+    milestone INT DEFAULT 0,
+    actor_id BIGINT UNSIGNED NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_balance_entries_user_id (user_id),
+    INDEX idx_balance_entries_type (type),
+    INDEX idx_balance_entries_news_id (news_id),
+    INDEX idx_balance_entries_created_at (created_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Reward policies; the row without category_id is the default
+CREATE TABLE IF NOT EXISTS reward_policies (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    category_id BIGINT UNSIGNED NULL UNIQUE,
+    base_amount DECIMAL(15,2) DEFAULT 0.00,
+    word_count_tiers TEXT,
+    thumbnail_bonus DECIMAL(15,2) DEFAULT 0.00,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Bonuses paid once when a published article reaches a view count
+CREATE TABLE IF NOT EXISTS reward_milestones (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    views INT NOT NULL UNIQUE,
+    bonus DECIMAL(15,2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Milestone bonuses claimed before they are sent to xinxun.us, so a bonus
+-- is never sent twice
+CREATE TABLE IF NOT EXISTS milestone_payouts (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    news_id BIGINT UNSIGNED NOT NULL,
+    milestone BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_news_milestone (news_id, milestone)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Editor-curated article collections (homepage-hero, editor-picks, ...)
+CREATE TABLE IF NOT EXISTS collections (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Articles in a collection, ordered by position and shown inside their window
+CREATE TABLE IF NOT EXISTS collection_items (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    collection_id BIGINT UNSIGNED NOT NULL,
+    news_id BIGINT UNSIGNED NOT NULL,
+    position INT DEFAULT 0,
+    starts_at TIMESTAMP NULL DEFAULT NULL,
+    expires_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_collection_news (collection_id, news_id),
+    FOREIGN KEY (collection_id) REFERENCES collections(id) ON DELETE CASCADE,
+    FOREIGN KEY (news_id) REFERENCES news(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Timestamped entries of live blog articles
+CREATE TABLE IF NOT EXISTS live_updates (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    news_id BIGINT UNSIGNED NOT NULL,
+    author_id BIGINT UNSIGNED NOT NULL,
+    content TEXT NOT NULL,
+    content_blocks MEDIUMTEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+    INDEX idx_news_id (news_id),
+    INDEX idx_created_at (created_at),
+    INDEX idx_updated_at (updated_at),
+    INDEX idx_deleted_at (deleted_at),
+    FOREIGN KEY (news_id) REFERENCES news(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- LSH bands of each article's content signature, used to pick the articles
+-- compared with a new submission
+CREATE TABLE IF NOT EXISTS signature_bands (
+    news_id BIGINT UNSIGNED NOT NULL,
+    band TINYINT UNSIGNED NOT NULL,
+    value BIGINT UNSIGNED NOT NULL,
+    PRIMARY KEY (news_id, band),
+    INDEX idx_band_value (band, value)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,165 @@
+// Package apierror defines the error responses of the API. Every error has
+// a stable snake_case code clients can branch on, an HTTP status and a
+// message translated from the i18n catalogs using the request's
+// Accept-Language header. The code doubles as the catalog key.
+//
+// Error bodies look like:
+//
+//	{"error": "Artikel tidak ditemukan", "code": "news_not_found"}
+//
+// with an optional "details" list of field errors and extra fields such as
+// "retry_after".
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"xinxun-news/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Error is an API error response
+type Error struct {
+	Status  int
+	Code    string
+	Args    []interface{} // Formatting arguments of the catalog message
+	Details []FieldError
+	Extra   gin.H // Additional top-level response fields
+	Err     error // Underlying cause, logged and never sent to clients
+}
+
+// FieldError describes a problem with one request field. Its message is
+// translated when the response is written.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+
+	args []interface{}
+}
+
+// Field builds a field error
+func Field(field, code string, args ...interface{}) FieldError {
+	return FieldError{Field: field, Code: code, args: args}
+}
+
+// New builds an error with an explicit status
+func New(status int, code string, args ...interface{}) *Error {
+	return &Error{Status: status, Code: code, Args: args}
+}
+
+func BadRequest(code string, args ...interface{}) *Error {
+	return New(http.StatusBadRequest, code, args...)
+}
+
+func Unauthorized(code string, args ...interface{}) *Error {
+	return New(http.StatusUnauthorized, code, args...)
+}
+
+func Forbidden(code string, args ...interface{}) *Error {
+	return New(http.StatusForbidden, code, args...)
+}
+
+func NotFound(code string, args ...interface{}) *Error {
+	return New(http.StatusNotFound, code, args...)
+}
+
+func Conflict(code string, args ...interface{}) *Error {
+	return New(http.StatusConflict, code, args...)
+}
+
+// Internal wraps an unexpected error. Clients only see a generic message;
+// the cause is logged.
+func Internal(err error) *Error {
+	return &Error{Status: http.StatusInternalServerError, Code: CodeInternal, Err: err}
+}
+
+// Generic codes shared by every endpoint
+const (
+	CodeInternal      = "internal_error"
+	CodeInvalidBody   = "invalid_request_body"
+	CodeRouteNotFound = "route_not_found"
+)
+
+// WithDetails adds field errors
+func (e *Error) WithDetails(details ...FieldError) *Error {
+	e.Details = append(e.Details, details...)
+	return e
+}
+
+// With adds an extra top-level field to the response body
+func (e *Error) With(key string, value interface{}) *Error {
+	if e.Extra == nil {
+		e.Extra = gin.H{}
+	}
+	e.Extra[key] = value
+	return e
+}
+
+// Wrap records the underlying cause
+func (e *Error) Wrap(err error) *Error {
+	e.Err = err
+	return e
+}
+
+// Error returns the message in the default language, followed by the cause
+func (e *Error) Error() string {
+	message := i18n.T(i18n.Default, e.Code, e.Args...)
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", message, e.Err)
+	}
+	return message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Describe returns the code and the localized message of err, for
+// responses that report the outcome of several operations at once. Like
+// Respond, it treats other errors as internal errors and logs their cause.
+func Describe(c *gin.Context, err error) (code, message string) {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		apiErr = Internal(err)
+	}
+	if apiErr.Status >= http.StatusInternalServerError && apiErr.Err != nil {
+		log.Printf("[API] %s %s: %s: %v", c.Request.Method, c.Request.URL.Path, apiErr.Code, apiErr.Err)
+	}
+	lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	return apiErr.Code, i18n.T(lang, apiErr.Code, apiErr.Args...)
+}
+
+// Respond writes err as the response and aborts the handler chain. Errors
+// that are not an *Error are treated as internal errors.
+func Respond(c *gin.Context, err error) {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		apiErr = Internal(err)
+	}
+	if apiErr.Status >= http.StatusInternalServerError && apiErr.Err != nil {
+		log.Printf("[API] %s %s: %s: %v", c.Request.Method, c.Request.URL.Path, apiErr.Code, apiErr.Err)
+	}
+
+	lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	body := gin.H{}
+	for key, value := range apiErr.Extra {
+		body[key] = value
+	}
+	body["error"] = i18n.T(lang, apiErr.Code, apiErr.Args...)
+	body["code"] = apiErr.Code
+	if len(apiErr.Details) > 0 {
+		details := make([]FieldError, len(apiErr.Details))
+		for i, detail := range apiErr.Details {
+			detail.Message = i18n.T(lang, detail.Code, detail.args...)
+			details[i] = detail
+		}
+		body["details"] = details
+	}
+
+	c.AbortWithStatusJSON(apiErr.Status, body)
+}
@@ -0,0 +1,65 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Report fields by their JSON name instead of the Go struct field name
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return field.Name
+			}
+			return name
+		})
+	}
+}
+
+// Bind converts the error of ShouldBindJSON into an invalid_request_body
+// error with one detail per invalid field. Validation tags map to the
+// field_<tag> codes (field_required, field_email, ...); min and max on text
+// become field_min_length and field_max_length.
+func Bind(err error) *Error {
+	apiErr := BadRequest(CodeInvalidBody).Wrap(err)
+
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &validationErrs):
+		for _, fieldErr := range validationErrs {
+			var args []interface{}
+			if param := fieldErr.Param(); param != "" {
+				args = append(args, param)
+			}
+			apiErr.WithDetails(Field(fieldErr.Field(), fieldCode(fieldErr), args...))
+		}
+	case errors.As(err, &typeErr):
+		apiErr.WithDetails(Field(typeErr.Field, "field_invalid_type", typeErr.Type.String()))
+	}
+	return apiErr
+}
+
+func fieldCode(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required", "email":
+		return "field_" + fieldErr.Tag()
+	case "min", "max":
+		if fieldErr.Kind() == reflect.String {
+			return "field_" + fieldErr.Tag() + "_length"
+		}
+		return "field_" + fieldErr.Tag()
+	default:
+		return "field_invalid"
+	}
+}
@@ -0,0 +1,273 @@
+// Package blocks validates structured article content and renders it to
+// HTML and plain text. Inline HTML in blocks is cleaned with the active
+// sanitize policy, and image and embed sources must be allowed by it.
+package blocks
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"xinxun-news/internal/i18n"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/sanitize"
+)
+
+const (
+	maxBlocks        = 500
+	maxListItems     = 100
+	maxGalleryImages = 30
+)
+
+// Error is a validation problem. Code is a stable message code of the i18n
+// catalogs, formatted with Args. Block is the 1-based position of the
+// offending block, or 0 when the problem is with the list as a whole.
+type Error struct {
+	Block int
+	Type  string
+	Code  string
+	Args  []interface{}
+}
+
+func (e *Error) Error() string {
+	message := i18n.T(i18n.Default, e.Code, e.Args...)
+	if e.Block == 0 {
+		return message
+	}
+	return fmt.Sprintf("block %d (%s): %s", e.Block, e.Type, message)
+}
+
+func invalid(code string, args ...interface{}) *Error {
+	return &Error{Code: code, Args: args}
+}
+
+// Normalize validates blocks and returns a cleaned copy: inline HTML is
+// sanitized, plain text fields are trimmed and heading levels default to 2.
+// Errors are always an *Error.
+func Normalize(list models.ContentBlocks) (models.ContentBlocks, error) {
+	if len(list) == 0 {
+		return nil, invalid("content_required")
+	}
+	if len(list) > maxBlocks {
+		return nil, invalid("content_too_many_blocks", maxBlocks)
+	}
+
+	result := make(models.ContentBlocks, 0, len(list))
+	for i, block := range list {
+		cleaned, err := normalizeBlock(block)
+		if err != nil {
+			err.Block, err.Type = i+1, block.Type
+			return nil, err
+		}
+		result = append(result, cleaned)
+	}
+	return result, nil
+}
+
+func normalizeBlock(b models.ContentBlock) (models.ContentBlock, *Error) {
+	out := models.ContentBlock{Type: b.Type}
+
+	switch b.Type {
+	case models.BlockTypeParagraph, models.BlockTypeHeading, models.BlockTypeQuote:
+		out.Text = strings.TrimSpace(sanitize.Content(b.Text))
+		if out.Text == "" {
+			return out, invalid("block_text_required")
+		}
+		if b.Type == models.BlockTypeHeading {
+			out.Level = b.Level
+			if out.Level == 0 {
+				out.Level = 2
+			}
+			if out.Level < 2 || out.Level > 6 {
+				return out, invalid("block_heading_level_invalid")
+			}
+		}
+		if b.Type == models.BlockTypeQuote {
+			out.Caption = strings.TrimSpace(b.Caption)
+		}
+
+	case models.BlockTypeImage:
+		out.URL = strings.TrimSpace(b.URL)
+		if !sanitize.ImageAllowed(out.URL) {
+			return out, invalid("block_image_not_allowed")
+		}
+		out.Alt = strings.TrimSpace(b.Alt)
+		out.Caption = strings.TrimSpace(b.Caption)
+
+	case models.BlockTypeEmbed:
+		out.URL = strings.TrimSpace(b.URL)
+		if !sanitize.EmbedAllowed(out.URL) {
+			return out, invalid("block_embed_not_allowed")
+		}
+		out.Caption = strings.TrimSpace(b.Caption)
+
+	case models.BlockTypeGallery:
+		if len(b.Images) == 0 {
+			return out, invalid("block_gallery_empty")
+		}
+		if len(b.Images) > maxGalleryImages {
+			return out, invalid("block_gallery_too_many_images", maxGalleryImages)
+		}
+		for i, image := range b.Images {
+			image.URL = strings.TrimSpace(image.URL)
+			if !sanitize.ImageAllowed(image.URL) {
+				return out, invalid("block_gallery_image_not_allowed", i+1)
+			}
+			image.Alt = strings.TrimSpace(image.Alt)
+			image.Caption = strings.TrimSpace(image.Caption)
+			out.Images = append(out.Images, image)
+		}
+		out.Caption = strings.TrimSpace(b.Caption)
+
+	case models.BlockTypeList:
+		if len(b.Items) > maxListItems {
+			return out, invalid("block_list_too_many_items", maxListItems)
+		}
+		for _, item := range b.Items {
+			if item = strings.TrimSpace(sanitize.Content(item)); item != "" {
+				out.Items = append(out.Items, item)
+			}
+		}
+		if len(out.Items) == 0 {
+			return out, invalid("block_list_empty")
+		}
+		out.Ordered = b.Ordered
+
+	case models.BlockTypeCode:
+		if strings.TrimSpace(b.Text) == "" {
+			return out, invalid("block_code_empty")
+		}
+		out.Text = b.Text
+		out.Language = strings.TrimSpace(b.Language)
+
+	default:
+		return out, invalid("block_type_unknown")
+	}
+	return out, nil
+}
+
+// HTML renders normalized blocks to article HTML
+func HTML(list models.ContentBlocks) string {
+	var out strings.Builder
+	for _, b := range list {
+		switch b.Type {
+		case models.BlockTypeParagraph:
+			out.WriteString("<p>" + b.Text + "</p>")
+		case models.BlockTypeHeading:
+			level := strconv.Itoa(b.Level)
+			out.WriteString("<h" + level + ">" + b.Text + "</h" + level + ">")
+		case models.BlockTypeQuote:
+			out.WriteString("<blockquote>" + b.Text)
+			if b.Caption != "" {
+				out.WriteString("<br>— " + html.EscapeString(b.Caption))
+			}
+			out.WriteString("</blockquote>")
+		case models.BlockTypeImage:
+			out.WriteString("<figure>")
+			writeImage(&out, b.URL, b.Alt)
+			writeCaption(&out, b.Caption)
+			out.WriteString("</figure>")
+		case models.BlockTypeEmbed:
+			out.WriteString(`<figure><iframe class="ql-video" frameborder="0" allowfullscreen="true" src="` +
+				html.EscapeString(b.URL) + `"></iframe>`)
+			writeCaption(&out, b.Caption)
+			out.WriteString("</figure>")
+		case models.BlockTypeGallery:
+			out.WriteString("<figure>")
+			for _, image := range b.Images {
+				writeImage(&out, image.URL, image.Alt)
+			}
+			writeCaption(&out, b.Caption)
+			out.WriteString("</figure>")
+		case models.BlockTypeList:
+			tag := "ul"
+			if b.Ordered {
+				tag = "ol"
+			}
+			out.WriteString("<" + tag + ">")
+			for _, item := range b.Items {
+				out.WriteString("<li>" + item + "</li>")
+			}
+			out.WriteString("</" + tag + ">")
+		case models.BlockTypeCode:
+			out.WriteString("<pre><code>" + html.EscapeString(b.Text) + "</code></pre>")
+		}
+	}
+	return sanitize.Content(out.String())
+}
+
+func writeImage(out *strings.Builder, url, alt string) {
+	out.WriteString(`<img src="` + html.EscapeString(url) + `"`)
+	if alt != "" {
+		out.WriteString(` alt="` + html.EscapeString(alt) + `"`)
+	}
+	out.WriteString(">")
+}
+
+func writeCaption(out *strings.Builder, caption string) {
+	if caption != "" {
+		out.WriteString("<figcaption>" + html.EscapeString(caption) + "</figcaption>")
+	}
+}
+
+// PlainText renders blocks to plain text, one paragraph per block. Images
+// and embeds contribute their captions.
+func PlainText(list models.ContentBlocks) string {
+	var parts []string
+	add := func(s string) {
+		if s = strings.TrimSpace(s); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	for _, b := range list {
+		switch b.Type {
+		case models.BlockTypeParagraph, models.BlockTypeHeading:
+			add(inlineText(b.Text))
+		case models.BlockTypeQuote:
+			text := inlineText(b.Text)
+			if b.Caption != "" {
+				text += "\n— " + b.Caption
+			}
+			add(text)
+		case models.BlockTypeImage, models.BlockTypeEmbed:
+			add(b.Caption)
+		case models.BlockTypeGallery:
+			var captions []string
+			for _, image := range b.Images {
+				if image.Caption != "" {
+					captions = append(captions, image.Caption)
+				}
+			}
+			if b.Caption != "" {
+				captions = append(captions, b.Caption)
+			}
+			add(strings.Join(captions, "\n"))
+		case models.BlockTypeList:
+			var items []string
+			for i, item := range b.Items {
+				marker := "- "
+				if b.Ordered {
+					marker = strconv.Itoa(i+1) + ". "
+				}
+				items = append(items, marker+inlineText(item))
+			}
+			add(strings.Join(items, "\n"))
+		case models.BlockTypeCode:
+			add(b.Text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// HTMLText renders raw article HTML to plain text, for articles that are
+// not written as blocks
+func HTMLText(content string) string {
+	return inlineText(content)
+}
+
+// inlineText strips the tags of inline HTML and collapses whitespace
+func inlineText(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(models.PlainText(s))), " ")
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -16,6 +17,43 @@ type Config struct {
 	JWTSecret  string
 	Port       string
 	CORSOrigin string
+
+	// AppURL is the public frontend URL used to build links in emails
+	AppURL string
+
+	// Mail settings. MailDriver is "smtp", "file" or "log" (default)
+	MailDriver   string
+	MailFrom     string
+	MailFilePath string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+
+	// Rate limits per route class as "<requests>/<duration>", e.g. "60/1m"
+	RateLimitEnabled bool
+	RateLimitRead    string
+	RateLimitLogin   string
+	RateLimitUpload  string
+	RateLimitWrite   string
+
+	// Reverse proxies (IPs or CIDRs) whose X-Forwarded-For header is trusted
+	// for the client IP. Empty trusts none and uses the connection address.
+	TrustedProxies []string
+
+	// How often view-milestone reward bonuses are paid, e.g. "10m"
+	RewardMilestoneInterval string
+
+	// How often expired articles are purged from the trash, e.g. "1h"
+	TrashPurgeInterval string
+
+	// Hosts article images and embeds may be loaded from (comma separated
+	// in the environment). Image hosts default to the S3 bucket domains.
+	ContentImageHosts []string
+	ContentEmbedHosts []string
+
+	// Languages articles can be written in, besides the default Indonesian
+	ContentLanguages []string
 }
 
 var AppConfig *Config
@@ -35,7 +73,57 @@ func LoadConfig() {
 		JWTSecret:  getEnv("JWT_SECRET", "your-secret-key"),
 		Port:       getEnv("PORT", "8080"),
 		CORSOrigin: getEnv("CORS_ORIGIN", "http://localhost:3000"),
+
+		AppURL: getEnv("APP_URL", "http://localhost:3000"),
+
+		MailDriver:   getEnv("MAIL_DRIVER", "log"),
+		MailFrom:     getEnv("MAIL_FROM", "no-reply@xinxun.us"),
+		MailFilePath: getEnv("MAIL_FILE_PATH", "mail.log"),
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+
+		RateLimitEnabled: getEnv("RATE_LIMIT_ENABLED", "true") == "true",
+		RateLimitRead:    getEnv("RATE_LIMIT_READ", "300/1m"),
+		RateLimitLogin:   getEnv("RATE_LIMIT_LOGIN", "10/1m"),
+		RateLimitUpload:  getEnv("RATE_LIMIT_UPLOAD", "30/1m"),
+		RateLimitWrite:   getEnv("RATE_LIMIT_WRITE", "60/1m"),
+
+		TrustedProxies: getEnvList("TRUSTED_PROXIES", ""),
+
+		RewardMilestoneInterval: getEnv("REWARD_MILESTONE_INTERVAL", "10m"),
+		TrashPurgeInterval:      getEnv("TRASH_PURGE_INTERVAL", "1h"),
+
+		ContentImageHosts: getEnvList("CONTENT_IMAGE_HOSTS", defaultImageHosts()),
+		ContentEmbedHosts: getEnvList("CONTENT_EMBED_HOSTS", "www.youtube.com,www.youtube-nocookie.com,player.vimeo.com"),
+
+		ContentLanguages: getEnvList("CONTENT_LANGUAGES", "en,zh"),
+	}
+}
+
+// defaultImageHosts returns the public domains of the configured S3 bucket
+func defaultImageHosts() string {
+	bucket := os.Getenv("AWS_S3_BUCKET")
+	if bucket == "" {
+		return ""
+	}
+	hosts := bucket + ".s3.amazonaws.com"
+	if region := os.Getenv("AWS_REGION"); region != "" {
+		hosts += "," + bucket + ".s3." + region + ".amazonaws.com"
+	}
+	return hosts
+}
+
+// getEnvList splits a comma separated variable, skipping empty entries
+func getEnvList(key, defaultValue string) []string {
+	var list []string
+	for _, item := range strings.Split(getEnv(key, defaultValue), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
 	}
+	return list
 }
 
 func getEnv(key, defaultValue string) string {
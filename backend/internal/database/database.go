@@ -3,6 +3,7 @@ package database
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"xinxun-news/internal/config"
 	"xinxun-news/internal/models"
@@ -41,7 +42,20 @@ func Migrate() {
 		&models.User{},
 		&models.Category{},
 		&models.Tag{},
+		&models.TagRedirect{},
 		&models.News{},
+		&models.UserToken{},
+		&models.RecoveryCode{},
+		&models.Setting{},
+		&models.AuditLog{},
+		&models.BalanceEntry{},
+		&models.RewardPolicy{},
+		&models.RewardMilestone{},
+		&models.MilestonePayout{},
+		&models.Collection{},
+		&models.CollectionItem{},
+		&models.LiveUpdate{},
+		&models.SignatureBand{},
 	)
 
 	if err != nil {
@@ -61,5 +75,65 @@ func Migrate() {
 			log.Println("revision_of column added successfully")
 		}
 	}
+
+	// AutoMigrate does not add new values to an existing enum column
+	extendEnum(&models.User{}, "users", "user_type", "editor",
+		"ENUM('admin','editor','publisher') DEFAULT 'admin'")
+	extendEnum(&models.News{}, "news", "status", "archived",
+		"ENUM('draft','published','pending','rejected','unpublished','archived') DEFAULT 'draft'")
+
+	backfillSignatureBands()
+	seedOpeningBalances()
+}
+
+// seedOpeningBalances gives every user with a balance but no ledger entries
+// an opening entry equal to users.balance, so the ledger sum starts from the
+// balance kept before the ledger existed
+func seedOpeningBalances() {
+	result := DB.Exec(`INSERT INTO balance_entries (user_id, type, amount, balance_after, reason, created_at)
+		SELECT users.id, ?, users.balance, users.balance, ?, NOW() FROM users
+		WHERE users.balance <> 0 AND NOT EXISTS (SELECT 1 FROM balance_entries WHERE balance_entries.user_id = users.id)`,
+		models.BalanceEntryOpening, "Saldo awal sebelum ledger")
+	if result.Error != nil {
+		log.Printf("Warning: Could not seed opening balances: %v", result.Error)
+	} else if result.RowsAffected > 0 {
+		log.Printf("Seeded opening balances for %d users", result.RowsAffected)
+	}
+}
+
+// backfillSignatureBands indexes the signatures of articles saved before
+// signature bands existed
+func backfillSignatureBands() {
+	var news []models.News
+	err := DB.Unscoped().Select("id", "content_signature").
+		Where("content_signature <> '' AND id NOT IN (?)", DB.Model(&models.SignatureBand{}).Select("news_id")).
+		FindInBatches(&news, 200, func(tx *gorm.DB, batch int) error {
+			for _, item := range news {
+				if err := models.ReplaceSignatureBands(DB, item.ID, item.ContentSignature); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		log.Printf("Warning: Could not index content signatures: %v", err)
+	}
+}
+
+// extendEnum redefines an enum column when it does not contain value yet
+func extendEnum(model interface{}, table, column, value, definition string) {
+	columns, err := DB.Migrator().ColumnTypes(model)
+	if err != nil {
+		log.Printf("Warning: Could not read columns of %s: %v", table, err)
+		return
+	}
+	for _, c := range columns {
+		if columnType, _ := c.ColumnType(); c.Name() == column && !strings.Contains(columnType, "'"+value+"'") {
+			log.Printf("Extending %s.%s with %s...", table, column, value)
+			if err := DB.Exec(fmt.Sprintf("ALTER TABLE %s MODIFY %s %s", table, column, definition)).Error; err != nil {
+				log.Printf("Warning: Could not extend %s.%s: %v", table, column, err)
+			}
+		}
+	}
 }
 
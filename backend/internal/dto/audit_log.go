@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"xinxun-news/internal/models"
+)
+
+// AuditLog is the admin view of an audit log entry
+type AuditLog struct {
+	ID         uint                `json:"id"`
+	Actor      *PublicUser         `json:"actor"`
+	ActorType  string              `json:"actor_type"`
+	Action     string              `json:"action"`
+	TargetType string              `json:"target_type"`
+	TargetID   uint                `json:"target_id"`
+	Changes    models.AuditChanges `json:"changes"`
+	IPAddress  string              `json:"ip_address"`
+	UserAgent  string              `json:"user_agent"`
+	CreatedAt  time.Time           `json:"created_at"`
+}
+
+func NewAuditLog(log models.AuditLog) AuditLog {
+	item := AuditLog{
+		ID:         log.ID,
+		ActorType:  log.ActorType,
+		Action:     log.Action,
+		TargetType: log.TargetType,
+		TargetID:   log.TargetID,
+		Changes:    log.Changes,
+		IPAddress:  log.IPAddress,
+		UserAgent:  log.UserAgent,
+		CreatedAt:  log.CreatedAt,
+	}
+	if log.Actor != nil {
+		item.Actor = NewPublicUser(*log.Actor)
+	}
+	return item
+}
+
+func NewAuditLogs(logs []models.AuditLog) []AuditLog {
+	items := make([]AuditLog, len(logs))
+	for i, log := range logs {
+		items[i] = NewAuditLog(log)
+	}
+	return items
+}
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"xinxun-news/internal/models"
+)
+
+// NewsRef is the minimal reference to an article shown next to a ledger entry
+type NewsRef struct {
+	ID    uint   `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
+
+// BalanceEntry is one movement in a publisher's balance ledger
+type BalanceEntry struct {
+	ID           uint                    `json:"id"`
+	Type         models.BalanceEntryType `json:"type"`
+	Amount       models.Money            `json:"amount"`
+	BalanceAfter models.Money            `json:"balance_after"`
+	News         *NewsRef                `json:"news"`
+	Reason       string                  `json:"reason"`
+	ActorID      *uint                   `json:"actor_id"`
+	CreatedAt    time.Time               `json:"created_at"`
+}
+
+func NewBalanceEntry(entry models.BalanceEntry) BalanceEntry {
+	item := BalanceEntry{
+		ID:           entry.ID,
+		Type:         entry.Type,
+		Amount:       entry.Amount,
+		BalanceAfter: entry.BalanceAfter,
+		Reason:       entry.Reason,
+		ActorID:      entry.ActorID,
+		CreatedAt:    entry.CreatedAt,
+	}
+	if entry.News != nil && entry.News.ID != 0 {
+		item.News = &NewsRef{ID: entry.News.ID, Title: entry.News.Title, Slug: entry.News.Slug}
+	}
+	return item
+}
+
+func NewBalanceEntries(entries []models.BalanceEntry) []BalanceEntry {
+	items := make([]BalanceEntry, len(entries))
+	for i, entry := range entries {
+		items[i] = NewBalanceEntry(entry)
+	}
+	return items
+}
@@ -0,0 +1,132 @@
+package dto
+
+import (
+	"time"
+
+	"xinxun-news/internal/models"
+)
+
+// PublicCategory keeps is_admin_only because the publisher editor uses it
+// to hide categories it cannot publish to
+type PublicCategory struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	ParentID    *uint  `json:"parent_id"`
+	IsAdminOnly bool   `json:"is_admin_only"`
+	Order       int    `json:"order"`
+}
+
+type AdminCategory struct {
+	PublicCategory
+	Names     models.LocalizedNames `json:"names"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+func NewPublicCategory(category models.Category) *PublicCategory {
+	if category.ID == 0 {
+		return nil
+	}
+	return &PublicCategory{
+		ID:          category.ID,
+		Name:        category.Name,
+		Slug:        category.Slug,
+		ParentID:    category.ParentID,
+		IsAdminOnly: category.IsAdminOnly,
+		Order:       category.Order,
+	}
+}
+
+func NewAdminCategory(category models.Category) *AdminCategory {
+	if category.ID == 0 {
+		return nil
+	}
+	return &AdminCategory{
+		PublicCategory: *NewPublicCategory(category),
+		Names:          category.Names,
+		CreatedAt:      category.CreatedAt,
+		UpdatedAt:      category.UpdatedAt,
+	}
+}
+
+func NewPublicCategories(categories []models.Category) []PublicCategory {
+	result := make([]PublicCategory, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, *NewPublicCategory(category))
+	}
+	return result
+}
+
+func NewAdminCategories(categories []models.Category) []AdminCategory {
+	result := make([]AdminCategory, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, *NewAdminCategory(category))
+	}
+	return result
+}
+
+// CategoryNode is a category with its subcategories
+type CategoryNode struct {
+	PublicCategory
+	Children []CategoryNode `json:"children"`
+}
+
+// NewCategoryTree nests categories under their parents. Categories must be
+// sorted in display order; children keep that order. Categories whose
+// parent is missing from the list become roots.
+func NewCategoryTree(categories []models.Category) []CategoryNode {
+	present := make(map[uint]bool, len(categories))
+	for _, category := range categories {
+		present[category.ID] = true
+	}
+	children := make(map[uint][]models.Category)
+	var roots []models.Category
+	for _, category := range categories {
+		if category.ParentID != nil && present[*category.ParentID] && *category.ParentID != category.ID {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+
+	// seen guards against cycles left in the data
+	seen := make(map[uint]bool, len(categories))
+	var build func(list []models.Category) []CategoryNode
+	build = func(list []models.Category) []CategoryNode {
+		nodes := make([]CategoryNode, 0, len(list))
+		for _, category := range list {
+			if seen[category.ID] {
+				continue
+			}
+			seen[category.ID] = true
+			nodes = append(nodes, CategoryNode{
+				PublicCategory: *NewPublicCategory(category),
+				Children:       build(children[category.ID]),
+			})
+		}
+		return nodes
+	}
+	tree := build(roots)
+	for _, category := range categories {
+		if !seen[category.ID] {
+			tree = append(tree, build([]models.Category{category})...)
+		}
+	}
+	return tree
+}
+
+// Breadcrumb is one step of the category path of an article
+type Breadcrumb struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+func NewBreadcrumbs(path []models.Category) []Breadcrumb {
+	result := make([]Breadcrumb, 0, len(path))
+	for _, category := range path {
+		result = append(result, Breadcrumb{ID: category.ID, Name: category.Name, Slug: category.Slug})
+	}
+	return result
+}
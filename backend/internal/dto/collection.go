@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"xinxun-news/internal/models"
+)
+
+// PublicCollection is a curated collection with the articles currently
+// shown in it
+type PublicCollection struct {
+	ID          uint           `json:"id"`
+	Name        string         `json:"name"`
+	Slug        string         `json:"slug"`
+	Description string         `json:"description"`
+	News        []NewsListItem `json:"news"`
+}
+
+// AdminCollection lists every item of a collection, including the ones
+// outside their display window
+type AdminCollection struct {
+	ID          uint             `json:"id"`
+	Name        string           `json:"name"`
+	Slug        string           `json:"slug"`
+	Description string           `json:"description"`
+	Items       []CollectionItem `json:"items,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// CollectionItem is one article of a collection. Active tells whether it
+// is shown right now.
+type CollectionItem struct {
+	NewsID    uint          `json:"news_id"`
+	News      *NewsListItem `json:"news,omitempty"`
+	Position  int           `json:"position"`
+	StartsAt  *time.Time    `json:"starts_at"`
+	ExpiresAt *time.Time    `json:"expires_at"`
+	Active    bool          `json:"active"`
+}
+
+func NewPublicCollection(collection models.Collection, news []models.News) PublicCollection {
+	return PublicCollection{
+		ID:          collection.ID,
+		Name:        collection.Name,
+		Slug:        collection.Slug,
+		Description: collection.Description,
+		News:        NewNewsList(news),
+	}
+}
+
+func NewAdminCollection(collection models.Collection) AdminCollection {
+	result := AdminCollection{
+		ID:          collection.ID,
+		Name:        collection.Name,
+		Slug:        collection.Slug,
+		Description: collection.Description,
+		CreatedAt:   collection.CreatedAt,
+		UpdatedAt:   collection.UpdatedAt,
+	}
+	now := time.Now()
+	for _, item := range collection.Items {
+		entry := CollectionItem{
+			NewsID:    item.NewsID,
+			Position:  item.Position,
+			StartsAt:  item.StartsAt,
+			ExpiresAt: item.ExpiresAt,
+			Active:    item.Active(now),
+		}
+		if item.News != nil {
+			news := NewNewsListItem(*item.News)
+			entry.News = &news
+			entry.Active = entry.Active && item.News.Status == models.StatusPublished
+		} else {
+			// The article is in the trash
+			entry.Active = false
+		}
+		result.Items = append(result.Items, entry)
+	}
+	return result
+}
+
+func NewAdminCollections(collections []models.Collection) []AdminCollection {
+	result := make([]AdminCollection, 0, len(collections))
+	for _, collection := range collections {
+		result = append(result, NewAdminCollection(collection))
+	}
+	return result
+}
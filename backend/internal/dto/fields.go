@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ParseFields splits a sparse fieldset parameter such as "title,slug,thumbnail".
+// An empty parameter returns nil, meaning all fields.
+func ParseFields(raw string) []string {
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// SelectFields reduces every item to the requested JSON fields. The "id"
+// field is always kept so clients can still key the items. Unknown field
+// names return an error.
+func SelectFields[T any](items []T, fields []string) ([]map[string]interface{}, error) {
+	allowed := jsonFieldNames(reflect.TypeOf((*T)(nil)).Elem())
+	for _, field := range fields {
+		if !allowed[field] {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	result := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var full map[string]interface{}
+		if err := json.Unmarshal(raw, &full); err != nil {
+			return nil, err
+		}
+
+		picked := map[string]interface{}{"id": full["id"]}
+		for _, field := range fields {
+			if value, ok := full[field]; ok {
+				picked[field] = value
+			}
+		}
+		result = append(result, picked)
+	}
+	return result, nil
+}
+
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"time"
+
+	"xinxun-news/internal/models"
+)
+
+// LiveUpdate is one entry of a live blog
+type LiveUpdate struct {
+	ID            uint                 `json:"id"`
+	NewsID        uint                 `json:"news_id"`
+	Content       string               `json:"content"`
+	ContentBlocks models.ContentBlocks `json:"content_blocks,omitempty"`
+	Author        *PublicUser          `json:"author,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+}
+
+func NewLiveUpdate(update models.LiveUpdate) LiveUpdate {
+	return LiveUpdate{
+		ID:            update.ID,
+		NewsID:        update.NewsID,
+		Content:       update.Content,
+		ContentBlocks: update.ContentBlocks,
+		Author:        NewPublicUser(update.Author),
+		CreatedAt:     update.CreatedAt,
+		UpdatedAt:     update.UpdatedAt,
+	}
+}
+
+func NewLiveUpdates(updates []models.LiveUpdate) []LiveUpdate {
+	result := make([]LiveUpdate, 0, len(updates))
+	for _, update := range updates {
+		result = append(result, NewLiveUpdate(update))
+	}
+	return result
+}
@@ -0,0 +1,250 @@
+package dto
+
+import (
+	"time"
+
+	"xinxun-news/internal/models"
+)
+
+// NewsListItem is the lean representation of an article used by list
+// endpoints. It deliberately leaves out the article content.
+type NewsListItem struct {
+	ID          uint              `json:"id"`
+	Title       string            `json:"title"`
+	Slug        string            `json:"slug"`
+	Excerpt     string            `json:"excerpt"`
+	Thumbnail   string            `json:"thumbnail"`
+	CategoryID  uint              `json:"category_id"`
+	Category    *PublicCategory   `json:"category,omitempty"`
+	AuthorID    uint              `json:"author_id"`
+	Author      *PublicUser       `json:"author,omitempty"`
+	Tags        []PublicTag       `json:"tags"`
+	PublishedAt *time.Time        `json:"published_at"`
+	Views       int               `json:"views"`
+	WordCount   int               `json:"word_count"`
+	ReadingTime int               `json:"reading_time"` // Minutes
+	Language    string            `json:"language"`
+	Status      models.NewsStatus `json:"status"`
+	RevisionOf  *uint             `json:"revision_of"`
+	IsPinned    bool              `json:"is_pinned"`
+	IsBreaking  bool              `json:"is_breaking"`
+	Type        models.NewsType   `json:"type"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	// SuggestedReward is only set in the admin pending queue
+	SuggestedReward *models.RewardSuggestion `json:"suggested_reward,omitempty"`
+	// SimilarityScore and SimilarMatches are only set in the admin pending queue
+	SimilarityScore *float64              `json:"similarity_score,omitempty"`
+	SimilarMatches  models.SimilarMatches `json:"similar_matches,omitempty"`
+}
+
+// PublicNews is the article detail served to anonymous readers
+type PublicNews struct {
+	ID          uint            `json:"id"`
+	Title       string          `json:"title"`
+	Slug        string          `json:"slug"`
+	Content     string          `json:"content"`
+	Excerpt     string          `json:"excerpt"`
+	Thumbnail   string          `json:"thumbnail"`
+	CategoryID  uint            `json:"category_id"`
+	Category    *PublicCategory `json:"category,omitempty"`
+	AuthorID    uint            `json:"author_id"`
+	Author      *PublicUser     `json:"author,omitempty"`
+	Tags        []PublicTag     `json:"tags"`
+	PublishedAt *time.Time      `json:"published_at"`
+	Views       int             `json:"views"`
+	WordCount   int             `json:"word_count"`
+	ReadingTime int             `json:"reading_time"` // Minutes
+	Language    string          `json:"language"`
+	Type        models.NewsType `json:"type"` // live_blog entries come from the updates endpoint
+	IsBreaking  bool            `json:"is_breaking"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	// Translations lists the published language versions of the article,
+	// itself included, for hreflang links. Only set on the public detail.
+	Translations []NewsTranslation `json:"translations,omitempty"`
+	// Breadcrumbs is the category path from the top-level category down to
+	// the article's category. Only set on the public detail.
+	Breadcrumbs []Breadcrumb `json:"breadcrumbs,omitempty"`
+}
+
+// NewsTranslation is one language version of an article
+type NewsTranslation struct {
+	ID       uint   `json:"id"`
+	Language string `json:"language"`
+	Title    string `json:"title"`
+	Slug     string `json:"slug"`
+	URL      string `json:"url"`
+}
+
+// NewsContent is the content of a published article in every available
+// format, for clients that render blocks themselves
+type NewsContent struct {
+	ID     uint                 `json:"id"`
+	Slug   string               `json:"slug"`
+	Format string               `json:"format"` // "blocks" or "html"
+	Blocks models.ContentBlocks `json:"blocks"`
+	HTML   string               `json:"html"`
+	Text   string               `json:"text"`
+}
+
+// PublisherNews adds the workflow and reward state a publisher needs
+// for their own articles
+type PublisherNews struct {
+	PublicNews
+	ContentBlocks models.ContentBlocks `json:"content_blocks,omitempty"`
+	AutoExcerpt   bool                 `json:"auto_excerpt"`
+	AutoThumbnail bool                 `json:"auto_thumbnail"`
+	Status        models.NewsStatus    `json:"status"`
+	RewardAmount  float64              `json:"reward_amount"`
+	IsRewarded    bool                 `json:"is_rewarded"`
+	RevisionOf    *uint                `json:"revision_of"`
+	// RejectionReason explains a rejection to the publisher
+	RejectionReason string `json:"rejection_reason,omitempty"`
+	// TranslationGroupID is shared by every language version of an article
+	TranslationGroupID *uint `json:"translation_group_id"`
+}
+
+// AdminNews exposes the full author and category records and the
+// duplicate check results to admins
+type AdminNews struct {
+	PublisherNews
+	Category        *AdminCategory        `json:"category,omitempty"`
+	Author          *AdminUser            `json:"author,omitempty"`
+	SimilarityScore float64               `json:"similarity_score"`
+	SimilarMatches  models.SimilarMatches `json:"similar_matches"`
+	Curation        NewsCuration          `json:"curation"`
+}
+
+// NewsCuration is the editor placement of an article: pinned to the top of
+// the news list, featured in the slider and/or flagged as breaking news,
+// each until an optional time
+type NewsCuration struct {
+	IsPinned      bool       `json:"is_pinned"`
+	PinnedUntil   *time.Time `json:"pinned_until"`
+	IsFeatured    bool       `json:"is_featured"`
+	FeaturedOrder int        `json:"featured_order"`
+	FeaturedUntil *time.Time `json:"featured_until"`
+	IsBreaking    bool       `json:"is_breaking"`
+	BreakingUntil *time.Time `json:"breaking_until"`
+}
+
+// TrashedNews is a deleted article in the admin trash listing. PurgeAt is
+// nil when the trash is only emptied by hand.
+type TrashedNews struct {
+	NewsListItem
+	DeletedAt time.Time  `json:"deleted_at"`
+	PurgeAt   *time.Time `json:"purge_at"`
+}
+
+func NewNewsListItem(news models.News) NewsListItem {
+	return NewsListItem{
+		ID:          news.ID,
+		Title:       news.Title,
+		Slug:        news.Slug,
+		Excerpt:     news.Excerpt,
+		Thumbnail:   news.Thumbnail,
+		CategoryID:  news.CategoryID,
+		Category:    NewPublicCategory(news.Category),
+		AuthorID:    news.AuthorID,
+		Author:      NewPublicUser(news.Author),
+		Tags:        NewPublicTags(news.Tags),
+		PublishedAt: news.PublishedAt,
+		Views:       news.Views,
+		WordCount:   news.WordCount,
+		ReadingTime: news.ReadingTime,
+		Language:    news.Language,
+		Status:      news.Status,
+		RevisionOf:  news.RevisionOf,
+		IsPinned:    news.PinnedActive(time.Now()),
+		IsBreaking:  news.BreakingActive(time.Now()),
+		Type:        news.Type,
+		CreatedAt:   news.CreatedAt,
+		UpdatedAt:   news.UpdatedAt,
+	}
+}
+
+func NewNewsList(news []models.News) []NewsListItem {
+	result := make([]NewsListItem, 0, len(news))
+	for _, item := range news {
+		result = append(result, NewNewsListItem(item))
+	}
+	return result
+}
+
+func NewPublicNews(news models.News) PublicNews {
+	return PublicNews{
+		ID:          news.ID,
+		Title:       news.Title,
+		Slug:        news.Slug,
+		Content:     news.Content,
+		Excerpt:     news.Excerpt,
+		Thumbnail:   news.Thumbnail,
+		CategoryID:  news.CategoryID,
+		Category:    NewPublicCategory(news.Category),
+		AuthorID:    news.AuthorID,
+		Author:      NewPublicUser(news.Author),
+		Tags:        NewPublicTags(news.Tags),
+		PublishedAt: news.PublishedAt,
+		Views:       news.Views,
+		WordCount:   news.WordCount,
+		ReadingTime: news.ReadingTime,
+		Language:    news.Language,
+		Type:        news.Type,
+		IsBreaking:  news.BreakingActive(time.Now()),
+		CreatedAt:   news.CreatedAt,
+		UpdatedAt:   news.UpdatedAt,
+	}
+}
+
+func NewPublisherNews(news models.News) PublisherNews {
+	return PublisherNews{
+		PublicNews:    NewPublicNews(news),
+		ContentBlocks: news.ContentBlocks,
+		AutoExcerpt:   news.AutoExcerpt,
+		AutoThumbnail: news.AutoThumbnail,
+		Status:        news.Status,
+		RewardAmount:  news.RewardAmount,
+		IsRewarded:    news.IsRewarded,
+		RevisionOf:    news.RevisionOf,
+
+		RejectionReason:    news.RejectionReason,
+		TranslationGroupID: news.TranslationGroupID,
+	}
+}
+
+func NewAdminNews(news models.News) AdminNews {
+	return AdminNews{
+		PublisherNews:   NewPublisherNews(news),
+		Category:        NewAdminCategory(news.Category),
+		Author:          NewAdminUser(news.Author),
+		SimilarityScore: news.SimilarityScore,
+		SimilarMatches:  news.SimilarMatches,
+		Curation:        NewNewsCuration(news),
+	}
+}
+
+func NewNewsCuration(news models.News) NewsCuration {
+	return NewsCuration{
+		IsPinned:      news.IsPinned,
+		PinnedUntil:   news.PinnedUntil,
+		IsFeatured:    news.IsFeatured,
+		FeaturedOrder: news.FeaturedOrder,
+		FeaturedUntil: news.FeaturedUntil,
+		IsBreaking:    news.IsBreaking,
+		BreakingUntil: news.BreakingUntil,
+	}
+}
+
+// NewNewsForRole picks the article representation matching the caller's
+// user type. Unknown or empty user types get the public representation.
+func NewNewsForRole(news models.News, userType string) interface{} {
+	switch models.UserType(userType) {
+	case models.UserTypeAdmin, models.UserTypeEditor:
+		return NewAdminNews(news)
+	case models.UserTypePublisher:
+		return NewPublisherNews(news)
+	default:
+		return NewPublicNews(news)
+	}
+}
@@ -0,0 +1,146 @@
+package dto
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"xinxun-news/internal/models"
+)
+
+// privateUserFields are account fields only the owner and admins may see
+var privateUserFields = []string{
+	"email", "balance", "xinxun_id", "xinxun_number", "reff_code",
+	"user_type", "must_change_password", "email_verified_at", "two_factor_enabled",
+}
+
+// privateNewsFields are review and payout details of an article
+var privateNewsFields = []string{
+	"reward_amount", "is_rewarded", "rejection_reason", "similarity_score",
+	"similar_matches", "suggested_reward", "curation",
+}
+
+func fullUser() models.User {
+	xinxunID := uint(77)
+	verified := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return models.User{
+		ID:                 7,
+		Username:           "budi",
+		Name:               "Budi Santoso",
+		Email:              "budi@example.com",
+		PasswordHash:       "hash",
+		UserType:           models.UserTypePublisher,
+		XinxunID:           &xinxunID,
+		XinxunNumber:       "628123456789",
+		Balance:            models.MoneyFromFloat(125000),
+		Status:             models.UserStatusActive,
+		ReffCode:           "REF123",
+		MustChangePassword: true,
+		EmailVerifiedAt:    &verified,
+		TwoFactorEnabled:   true,
+		TwoFactorSecret:    "SECRET",
+		DisplayName:        "Budi",
+		Bio:                "Reporter",
+	}
+}
+
+func fullNews() models.News {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return models.News{
+		ID:              1,
+		Title:           "Judul",
+		Slug:            "judul",
+		Content:         "<p>Isi</p>",
+		CategoryID:      2,
+		Category:        models.Category{ID: 2, Name: "Berita", Slug: "berita", Names: models.LocalizedNames{"en": "News"}},
+		AuthorID:        7,
+		Author:          fullUser(),
+		Tags:            []models.Tag{{ID: 3, Name: "Ekonomi", Slug: "ekonomi", Names: models.LocalizedNames{"en": "Economy"}}},
+		PublishedAt:     &now,
+		Status:          models.StatusPublished,
+		RewardAmount:    50000,
+		IsRewarded:      true,
+		RejectionReason: "alasan",
+		SimilarityScore: 0.4,
+	}
+}
+
+// jsonKeys marshals v and collects every object key at any depth
+func jsonKeys(t *testing.T, v interface{}) map[string]bool {
+	t.Helper()
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal %T: %v", v, err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal %T: %v", v, err)
+	}
+	keys := map[string]bool{}
+	var walk func(interface{})
+	walk = func(node interface{}) {
+		switch value := node.(type) {
+		case map[string]interface{}:
+			for key, child := range value {
+				keys[key] = true
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range value {
+				walk(child)
+			}
+		}
+	}
+	walk(decoded)
+	return keys
+}
+
+func TestPublicDTOsOmitPrivateFields(t *testing.T) {
+	news := fullNews()
+	tests := []struct {
+		name      string
+		value     interface{}
+		forbidden []string
+	}{
+		{"PublicUser", NewPublicUser(fullUser()), privateUserFields},
+		{"AuthorProfile", NewAuthorProfile(fullUser(), 4), privateUserFields},
+		{"PublicNews", NewPublicNews(news), append(privateUserFields, privateNewsFields...)},
+		{"NewsList", NewNewsList([]models.News{news}), append(privateUserFields, privateNewsFields...)},
+		{"PublicCollection", NewPublicCollection(models.Collection{ID: 1, Name: "Pilihan"}, []models.News{news}), append(privateUserFields, privateNewsFields...)},
+		{"PublicCategory", NewPublicCategory(news.Category), []string{"names", "created_at", "updated_at"}},
+		{"PublicTags", NewPublicTags(news.Tags), []string{"names", "created_at", "updated_at"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := jsonKeys(t, tt.value)
+			for _, field := range tt.forbidden {
+				if keys[field] {
+					t.Errorf("%s exposes %q", tt.name, field)
+				}
+			}
+		})
+	}
+}
+
+func TestPublicNewsKeepsAuthorIdentity(t *testing.T) {
+	keys := jsonKeys(t, NewPublicNews(fullNews()))
+	for _, field := range []string{"author", "username", "display_name", "category", "tags"} {
+		if !keys[field] {
+			t.Errorf("PublicNews is missing %q", field)
+		}
+	}
+}
+
+func TestPublisherUserShowsOwnAccount(t *testing.T) {
+	keys := jsonKeys(t, NewPublisherUser(fullUser()))
+	for _, field := range []string{"email", "balance", "xinxun_number", "two_factor_enabled"} {
+		if !keys[field] {
+			t.Errorf("PublisherUser is missing %q", field)
+		}
+	}
+	for _, field := range []string{"password_hash", "two_factor_secret"} {
+		if keys[field] {
+			t.Errorf("PublisherUser exposes %q", field)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"xinxun-news/internal/models"
+)
+
+type PublicTag struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Order int    `json:"order"`
+}
+
+type AdminTag struct {
+	PublicTag
+	Names     models.LocalizedNames `json:"names"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+// TagSuggestion is a tag proposed for an article, with how strongly it
+// matched the article text
+type TagSuggestion struct {
+	PublicTag
+	Score int `json:"score"`
+}
+
+func NewPublicTag(tag models.Tag) PublicTag {
+	return PublicTag{ID: tag.ID, Name: tag.Name, Slug: tag.Slug, Order: tag.Order}
+}
+
+func NewAdminTag(tag models.Tag) AdminTag {
+	return AdminTag{
+		PublicTag: NewPublicTag(tag),
+		Names:     tag.Names,
+		CreatedAt: tag.CreatedAt,
+		UpdatedAt: tag.UpdatedAt,
+	}
+}
+
+func NewPublicTags(tags []models.Tag) []PublicTag {
+	result := make([]PublicTag, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, NewPublicTag(tag))
+	}
+	return result
+}
+
+func NewAdminTags(tags []models.Tag) []AdminTag {
+	result := make([]AdminTag, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, NewAdminTag(tag))
+	}
+	return result
+}
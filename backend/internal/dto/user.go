@@ -0,0 +1,121 @@
+package dto
+
+import (
+	"time"
+
+	"xinxun-news/internal/models"
+)
+
+// PublicUser is what anonymous readers may see about an account,
+// typically the author of an article
+type PublicUser struct {
+	ID          uint   `json:"id"`
+	Username    string `json:"username"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	AvatarURL   string `json:"avatar_url"`
+}
+
+// AuthorProfile is the public author page
+type AuthorProfile struct {
+	PublicUser
+	Bio          string             `json:"bio"`
+	SocialLinks  models.SocialLinks `json:"social_links"`
+	ArticleCount int64              `json:"article_count"`
+	JoinedAt     time.Time          `json:"joined_at"`
+}
+
+// PublisherUser is the account as seen by its own owner
+type PublisherUser struct {
+	ID                 uint               `json:"id"`
+	Username           string             `json:"username"`
+	Name               string             `json:"name"`
+	Email              string             `json:"email"`
+	UserType           models.UserType    `json:"user_type"`
+	XinxunID           *uint              `json:"xinxun_id"`
+	XinxunNumber       string             `json:"xinxun_number"`
+	Balance            models.Money       `json:"balance"`
+	Status             string             `json:"status"`
+	ReffCode           string             `json:"reff_code"`
+	DisplayName        string             `json:"display_name"`
+	Bio                string             `json:"bio"`
+	AvatarURL          string             `json:"avatar_url"`
+	SocialLinks        models.SocialLinks `json:"social_links"`
+	MustChangePassword bool               `json:"must_change_password"`
+	EmailVerifiedAt    *time.Time         `json:"email_verified_at"`
+	TwoFactorEnabled   bool               `json:"two_factor_enabled"`
+	CreatedAt          time.Time          `json:"created_at"`
+}
+
+// AdminUser is the full account record for the admin panel
+type AdminUser struct {
+	PublisherUser
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewPublicUser(user models.User) *PublicUser {
+	if user.ID == 0 {
+		return nil
+	}
+	return &PublicUser{
+		ID:          user.ID,
+		Username:    user.Username,
+		Name:        user.PublicName(),
+		DisplayName: user.DisplayName,
+		AvatarURL:   user.AvatarURL,
+	}
+}
+
+func NewAuthorProfile(user models.User, articleCount int64) AuthorProfile {
+	return AuthorProfile{
+		PublicUser:   *NewPublicUser(user),
+		Bio:          user.Bio,
+		SocialLinks:  user.SocialLinks,
+		ArticleCount: articleCount,
+		JoinedAt:     user.CreatedAt,
+	}
+}
+
+func NewPublisherUser(user models.User) *PublisherUser {
+	if user.ID == 0 {
+		return nil
+	}
+	return &PublisherUser{
+		ID:                 user.ID,
+		Username:           user.Username,
+		Name:               user.Name,
+		Email:              user.Email,
+		UserType:           user.UserType,
+		XinxunID:           user.XinxunID,
+		XinxunNumber:       user.XinxunNumber,
+		Balance:            user.Balance,
+		Status:             user.Status,
+		ReffCode:           user.ReffCode,
+		DisplayName:        user.DisplayName,
+		Bio:                user.Bio,
+		AvatarURL:          user.AvatarURL,
+		SocialLinks:        user.SocialLinks,
+		MustChangePassword: user.MustChangePassword,
+		EmailVerifiedAt:    user.EmailVerifiedAt,
+		TwoFactorEnabled:   user.TwoFactorEnabled,
+		CreatedAt:          user.CreatedAt,
+	}
+}
+
+func NewAdminUser(user models.User) *AdminUser {
+	if user.ID == 0 {
+		return nil
+	}
+	return &AdminUser{
+		PublisherUser: *NewPublisherUser(user),
+		UpdatedAt:     user.UpdatedAt,
+	}
+}
+
+func NewAdminUsers(users []models.User) []AdminUser {
+	result := make([]AdminUser, 0, len(users))
+	for _, user := range users {
+		result = append(result, *NewAdminUser(user))
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"xinxun-news/internal/config"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+	"xinxun-news/internal/services"
+)
+
+const (
+	passwordResetTTL     = time.Hour
+	emailVerificationTTL = 48 * time.Hour
+)
+
+// issueUserToken replaces any outstanding token of the same purpose and
+// returns the raw token to put in the email link
+func issueUserToken(user *models.User, purpose models.TokenPurpose, ttl time.Duration) (string, error) {
+	tokenRepo := repository.NewTokenRepository()
+	if err := tokenRepo.InvalidateForUser(user.ID, purpose); err != nil {
+		return "", err
+	}
+
+	token, tokenHash, err := services.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = tokenRepo.Create(&models.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	return token, err
+}
+
+func appLink(path, token string) string {
+	return strings.TrimRight(config.AppConfig.AppURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+// sendPasswordResetEmail emails a password reset link to the user
+func sendPasswordResetEmail(user *models.User) error {
+	token, err := issueUserToken(user, models.TokenPurposePasswordReset, passwordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	return services.SendMail(services.MailMessage{
+		To:      user.Email,
+		Subject: "Reset password Xinxun News",
+		Body: fmt.Sprintf("Halo %s,\n\nKami menerima permintaan untuk mereset password akun Anda. "+
+			"Buka link berikut untuk membuat password baru (berlaku %d menit):\n\n%s\n\n"+
+			"Abaikan email ini jika Anda tidak memintanya.",
+			user.Name, int(passwordResetTTL.Minutes()), appLink("/admin/reset-password", token)),
+	})
+}
+
+// sendVerificationEmail emails an email verification link to the user
+func sendVerificationEmail(user *models.User) error {
+	token, err := issueUserToken(user, models.TokenPurposeEmailVerification, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return services.SendMail(services.MailMessage{
+		To:      user.Email,
+		Subject: "Verifikasi email Xinxun News",
+		Body: fmt.Sprintf("Halo %s,\n\nSilakan verifikasi alamat email akun Anda melalui link berikut "+
+			"(berlaku %d jam):\n\n%s",
+			user.Name, int(emailVerificationTTL.Hours()), appLink("/admin/verify-email", token)),
+	})
+}
+
+// sendVerificationEmailAsync sends the verification email in the background,
+// logging failures instead of failing the request
+func sendVerificationEmailAsync(user models.User) {
+	go func() {
+		if err := sendVerificationEmail(&user); err != nil {
+			log.Printf("[Mail] Failed to send verification email to user %d: %v", user.ID, err)
+		}
+	}()
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"net/http"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Actions of BulkNews
+const (
+	bulkApprove        = "approve"
+	bulkReject         = "reject"
+	bulkDelete         = "delete"
+	bulkChangeCategory = "change_category"
+	bulkAddTags        = "add_tags"
+	bulkRemoveTags     = "remove_tags"
+	bulkUnpublish      = "unpublish"
+	bulkArchive        = "archive"
+)
+
+type BulkNewsRequest struct {
+	IDs    []uint `json:"ids" binding:"required,min=1,max=100"`
+	Action string `json:"action" binding:"required"`
+
+	RewardAmount *float64 `json:"reward_amount"` // approve: overrides the suggested reward of every article
+	Reason       string   `json:"reason"`        // reject
+	CategoryID   uint     `json:"category_id"`   // change_category
+	TagIDs       []uint   `json:"tag_ids"`       // add_tags, remove_tags
+}
+
+// BulkNewsResult is the outcome of a bulk action on one article. Code and
+// Message explain a failure, or a warning when Success is true.
+type BulkNewsResult struct {
+	ID      uint   `json:"id"`
+	Success bool   `json:"success"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// BulkNews runs one action on several articles. Every article is handled on
+// its own: a failure is reported in its result and does not stop or undo
+// the others. Approve and reject stay admin-only, like their single
+// article endpoints.
+func (h *AdminHandler) BulkNews(c *gin.Context) {
+	if !models.UserType(userTypeString(c)).IsStaff() {
+		apierror.Respond(c, apierror.Forbidden("news_bulk_staff_only"))
+		return
+	}
+
+	var req BulkNewsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	isAdmin := userTypeString(c) == string(models.UserTypeAdmin)
+	var tags []models.Tag
+	switch req.Action {
+	case bulkApprove:
+		if !isAdmin {
+			apierror.Respond(c, apierror.Forbidden("news_approve_admin_only"))
+			return
+		}
+		if req.RewardAmount != nil && *req.RewardAmount < 0 {
+			apierror.Respond(c, apierror.BadRequest("reward_negative"))
+			return
+		}
+	case bulkReject:
+		if !isAdmin {
+			apierror.Respond(c, apierror.Forbidden("news_reject_admin_only"))
+			return
+		}
+	case bulkDelete, bulkUnpublish, bulkArchive:
+	case bulkChangeCategory:
+		if _, err := h.categoryRepo.FindByID(req.CategoryID); err != nil {
+			apierror.Respond(c, apierror.BadRequest("category_not_found"))
+			return
+		}
+	case bulkAddTags, bulkRemoveTags:
+		if len(req.TagIDs) == 0 {
+			apierror.Respond(c, apierror.BadRequest(apierror.CodeInvalidBody).
+				WithDetails(apierror.Field("tag_ids", "field_required")))
+			return
+		}
+		var ok bool
+		if tags, ok = resolveTags(c, req.TagIDs, nil); !ok {
+			return
+		}
+	default:
+		apierror.Respond(c, apierror.BadRequest("bulk_action_invalid", req.Action))
+		return
+	}
+
+	results := []BulkNewsResult{}
+	succeeded := 0
+	seen := map[uint]bool{}
+	for _, id := range req.IDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		result := BulkNewsResult{ID: id, Success: true}
+		warning, err := h.bulkNewsItem(c, id, req, tags)
+		switch {
+		case err != nil:
+			result.Success = false
+			result.Code, result.Message = apierror.Describe(c, err)
+		case warning != nil:
+			result.Code, result.Message = apierror.Describe(c, warning)
+		}
+		if result.Success {
+			succeeded++
+		}
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"action":    req.Action,
+			"results":   results,
+			"succeeded": succeeded,
+			"failed":    len(results) - succeeded,
+		},
+	})
+}
+
+// bulkNewsItem applies the action of a bulk request to one article. The
+// warning reports a problem that did not stop the action.
+func (h *AdminHandler) bulkNewsItem(c *gin.Context, id uint, req BulkNewsRequest, tags []models.Tag) (warning, err error) {
+	news, err := h.newsRepo.FindByID(id)
+	if err != nil {
+		return nil, apierror.NotFound("news_not_found")
+	}
+	before := auditSnapshot(news)
+
+	switch req.Action {
+	case bulkApprove:
+		result, err := h.approve(c, news, req.RewardAmount)
+		if err != nil {
+			return nil, err
+		}
+		if result.RewardErr != nil {
+			// The approval stands even when the reward could not be sent
+			return apierror.New(http.StatusBadGateway, "reward_send_failed").Wrap(result.RewardErr), nil
+		}
+		return nil, nil
+
+	case bulkReject:
+		return nil, h.reject(c, news, req.Reason)
+
+	case bulkDelete:
+		if err := h.newsRepo.Delete(id); err != nil {
+			return nil, apierror.Internal(err)
+		}
+		recordAudit(c, "news.delete", models.AuditTargetNews, id, before, nil)
+		return nil, nil
+
+	case bulkChangeCategory:
+		err = h.newsRepo.SetCategory(id, req.CategoryID)
+
+	case bulkAddTags:
+		err = h.newsRepo.AddTags(news, tags)
+
+	case bulkRemoveTags:
+		err = h.newsRepo.RemoveTags(news, tags)
+
+	case bulkUnpublish:
+		return nil, h.changeStatus(c, news, models.StatusUnpublished)
+
+	case bulkArchive:
+		return nil, h.changeStatus(c, news, models.StatusArchived)
+	}
+	if err != nil {
+		return nil, apierror.Internal(err)
+	}
+
+	updated, err := h.newsRepo.FindByID(id)
+	if err != nil {
+		return nil, apierror.Internal(err)
+	}
+	recordAudit(c, "news."+req.Action, models.AuditTargetNews, id, before, auditSnapshot(updated))
+	return nil, nil
+}
@@ -1,13 +1,20 @@
 package handlers
 
 import (
+	"io"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"xinxun-news/internal/apierror"
 	"xinxun-news/internal/database"
+	"xinxun-news/internal/dto"
 	"xinxun-news/internal/models"
 	"xinxun-news/internal/repository"
+	"xinxun-news/internal/rewards"
+	"xinxun-news/internal/sanitize"
 	"xinxun-news/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -15,27 +22,30 @@ import (
 )
 
 type AdminHandler struct {
-	newsRepo *repository.NewsRepository
-	userRepo *repository.UserRepository
+	newsRepo     *repository.NewsRepository
+	userRepo     *repository.UserRepository
+	categoryRepo *repository.CategoryRepository
 }
 
 func NewAdminHandler() *AdminHandler {
 	return &AdminHandler{
-		newsRepo: repository.NewNewsRepository(),
-		userRepo: repository.NewUserRepository(),
+		newsRepo:     repository.NewNewsRepository(),
+		userRepo:     repository.NewUserRepository(),
+		categoryRepo: repository.NewCategoryRepository(),
 	}
 }
 
 type ApproveNewsRequest struct {
-	RewardAmount float64 `json:"reward_amount"`
+	// RewardAmount overrides the reward suggested by the reward policies.
+	// Leave it out to pay the suggested amount.
+	RewardAmount *float64 `json:"reward_amount"`
 }
 
 // ApproveNews approves a pending news and gives reward to publisher
 func (h *AdminHandler) ApproveNews(c *gin.Context) {
-	// Verify user is admin
-	userType, exists := c.Get("user_type")
-	if !exists || userType != string(models.UserTypeAdmin) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Hanya admin yang dapat approve artikel"})
+	// Approving pays the reward, so editors cannot approve
+	if userTypeString(c) != string(models.UserTypeAdmin) {
+		apierror.Respond(c, apierror.Forbidden("news_approve_admin_only"))
 		return
 	}
 
@@ -43,38 +53,81 @@ func (h *AdminHandler) ApproveNews(c *gin.Context) {
 
 	news, err := h.newsRepo.FindByID(uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Artikel tidak ditemukan"})
+		apierror.Respond(c, apierror.NotFound("news_not_found"))
 		return
 	}
 
-	if news.Status != models.StatusPending {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Artikel tidak berstatus pending"})
+	var req ApproveNewsRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+	if req.RewardAmount != nil && *req.RewardAmount < 0 {
+		apierror.Respond(c, apierror.BadRequest("reward_negative"))
 		return
 	}
 
-	var req ApproveNewsRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	result, err := h.approve(c, news, req.RewardAmount)
+	if err != nil {
+		apierror.Respond(c, err)
 		return
 	}
 
+	if result.Revision {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Revisi berhasil diapprove dan artikel asli berhasil diupdate (tidak ada reward untuk revisi)",
+			"data":    dto.NewAdminNews(*result.News),
+		})
+		return
+	}
+	if result.RewardErr != nil {
+		// The approval stands even when the reward could not be sent
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Artikel berhasil diapprove tetapi reward gagal",
+			"error":   result.RewardErr.Error(),
+			"data":    dto.NewAdminNews(*result.News),
+			"reward":  result.Reward,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Artikel berhasil diapprove",
+		"data":    dto.NewAdminNews(*result.News),
+		"reward":  result.Reward,
+	})
+}
+
+// approveResult is the outcome of approving one article
+type approveResult struct {
+	News      *models.News
+	Revision  bool                     // A revision was merged into its original
+	Reward    *models.RewardSuggestion // Reward of a new article
+	RewardErr error                    // Sending the reward failed; the article is approved anyway
+}
+
+// approve publishes a pending article and pays its reward. A revision is
+// merged into the original article instead and never rewarded.
+// rewardAmount overrides the suggested reward when set.
+func (h *AdminHandler) approve(c *gin.Context, news *models.News, rewardAmount *float64) (*approveResult, error) {
+	if news.Status != models.StatusPending {
+		return nil, apierror.BadRequest("news_not_pending")
+	}
+	before := auditSnapshot(news)
+
 	// Get author (publisher)
 	author, err := h.userRepo.FindByID(news.AuthorID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Publisher tidak ditemukan"})
-		return
+		return nil, apierror.NotFound("publisher_not_found")
 	}
 
 	// Check if author is publisher
 	if author.UserType != models.UserTypePublisher {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Hanya artikel publisher yang dapat diapprove"})
-		return
+		return nil, apierror.BadRequest("news_not_from_publisher")
 	}
 
 	// Check if xinxun_id exists
 	if author.XinxunID == nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Publisher tidak memiliki xinxun_id"})
-		return
+		return nil, apierror.BadRequest("publisher_missing_xinxun_id")
 	}
 
 	// If this is a revision, update the original news instead
@@ -82,9 +135,9 @@ func (h *AdminHandler) ApproveNews(c *gin.Context) {
 	if news.RevisionOf != nil {
 		originalNews, err := h.newsRepo.FindByID(*news.RevisionOf)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Artikel asli tidak ditemukan"})
-			return
+			return nil, apierror.NotFound("original_news_not_found")
 		}
+		originalBefore := auditSnapshot(originalNews)
 
 		// Generate new slug if title changed, otherwise keep original slug
 		newSlug := originalNews.Slug
@@ -101,85 +154,93 @@ func (h *AdminHandler) ApproveNews(c *gin.Context) {
 		// Update original with revision data (no reward for edits)
 		originalNews.Title = news.Title
 		originalNews.Slug = newSlug
-		originalNews.Content = news.Content
+		originalNews.Content = sanitize.Content(news.Content)
+		originalNews.ContentBlocks = news.ContentBlocks
 		originalNews.Excerpt = news.Excerpt
 		originalNews.Thumbnail = news.Thumbnail
+		originalNews.AutoExcerpt = news.AutoExcerpt
+		originalNews.AutoThumbnail = news.AutoThumbnail
 		originalNews.CategoryID = news.CategoryID
 		originalNews.Tags = news.Tags
+		originalNews.Language = news.Language
+		originalNews.TranslationGroupID = news.TranslationGroupID
 		// No reward for revisions
 		originalNews.RewardAmount = 0
-		now := time.Now()
-		originalNews.PublishedAt = &now
-		originalNews.Status = models.StatusPublished
-
-		if err := h.newsRepo.Update(originalNews); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
+		// Only a published original is republished with the revision. An
+		// article staff unpublished, archived or rejected keeps its status;
+		// approving an edit does not bring it back.
+		if originalNews.Status == models.StatusPublished {
+			now := time.Now()
+			originalNews.PublishedAt = &now
 		}
 
-		// Delete the revision
-		if err := h.newsRepo.Delete(news.ID); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
+		// Update the original and delete the revision together
+		if err := h.newsRepo.ApplyRevision(originalNews, news.ID); err != nil {
+			return nil, apierror.Internal(err)
 		}
 
 		// Reload original with relations
 		updatedOriginal, err := h.newsRepo.FindByID(originalNews.ID)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
+			return nil, apierror.Internal(err)
 		}
+		recordAudit(c, "news.approve_revision", models.AuditTargetNews, updatedOriginal.ID, originalBefore, auditSnapshot(updatedOriginal))
 
-		c.JSON(http.StatusOK, gin.H{
-			"message": "Revisi berhasil diapprove dan artikel asli berhasil diupdate (tidak ada reward untuk revisi)",
-			"data":    updatedOriginal,
-		})
-		return
+		return &approveResult{News: updatedOriginal, Revision: true}, nil
 	}
 
 	// Regular approval (not a revision)
+	rule, err := rewards.Suggest(news)
+	if err != nil {
+		return nil, apierror.Internal(err)
+	}
+	if rewardAmount != nil {
+		rule.Manual = true
+		rule.Amount = models.MoneyFromFloat(*rewardAmount)
+	}
+
+	news.Content = sanitize.Content(news.Content)
 	news.Status = models.StatusPublished
-	news.RewardAmount = req.RewardAmount
+	news.RewardAmount = rule.Amount.Float64()
+	news.RejectionReason = ""
 	now := time.Now()
 	news.PublishedAt = &now
 
 	if err := h.newsRepo.Update(news); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		return nil, apierror.Internal(err)
 	}
 
+	result := &approveResult{News: news, Reward: rule}
+
 	// Send reward to publisher via xinxun.us API
 	// Use xinxun_id from user (which is the ID from xinxun.us API)
-	if req.RewardAmount > 0 && !news.IsRewarded && author.XinxunID != nil {
-		rewardResp, err := services.SendReward(*author.XinxunID, req.RewardAmount)
+	if rule.Amount > 0 && !news.IsRewarded && author.XinxunID != nil {
+		rewardResp, err := services.SendReward(*author.XinxunID, rule.Amount.Float64())
 		if err != nil {
 			// Log error but don't fail the approval
-			c.JSON(http.StatusOK, gin.H{
-				"message": "Artikel berhasil diapprove tetapi reward gagal",
-				"error":   err.Error(),
-				"data":    news,
-			})
-			return
-		}
-
-		if rewardResp.Success {
+			result.RewardErr = err
+		} else if rewardResp.Success {
 			news.IsRewarded = true
 			h.newsRepo.Update(news)
+			if err := recordReward(c, news, rule); err != nil {
+				log.Printf("[Balance] Failed to record reward for news %d: %v", news.ID, err)
+			}
 		}
 	}
+	recordAudit(c, "news.approve", models.AuditTargetNews, news.ID, before, auditSnapshot(news))
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Artikel berhasil diapprove",
-		"data":    news,
-	})
+	return result, nil
+}
+
+type RejectNewsRequest struct {
+	Reason string `json:"reason"` // Shown to the publisher (optional)
 }
 
 // RejectNews rejects a pending news
 func (h *AdminHandler) RejectNews(c *gin.Context) {
 	// Verify user is admin
-	userType, exists := c.Get("user_type")
-	if !exists || userType != string(models.UserTypeAdmin) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Hanya admin yang dapat reject artikel"})
+	if userTypeString(c) != string(models.UserTypeAdmin) {
+		apierror.Respond(c, apierror.Forbidden("news_reject_admin_only"))
 		return
 	}
 
@@ -187,65 +248,93 @@ func (h *AdminHandler) RejectNews(c *gin.Context) {
 
 	news, err := h.newsRepo.FindByID(uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Artikel tidak ditemukan"})
+		apierror.Respond(c, apierror.NotFound("news_not_found"))
 		return
 	}
 
-	if news.Status != models.StatusPending {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Artikel tidak berstatus pending"})
+	var req RejectNewsRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		apierror.Respond(c, apierror.Bind(err))
 		return
 	}
 
-	news.Status = models.StatusRejected
-	if err := h.newsRepo.Update(news); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.reject(c, news, req.Reason); err != nil {
+		apierror.Respond(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Artikel berhasil ditolak",
-		"data":    news,
+		"data":    dto.NewAdminNews(*news),
 	})
 }
 
-// GetPendingNews gets all pending news for admin review (new articles only, not revisions)
-func (h *AdminHandler) GetPendingNews(c *gin.Context) {
-	var news []models.News
-	err := database.DB.Preload("Category").Preload("Author").Preload("Tags").
-		Where("status = ? AND revision_of IS NULL", models.StatusPending).
-		Order("created_at DESC").
-		Find(&news).Error
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+// reject marks a pending article as rejected, keeping the reason for the
+// publisher
+func (h *AdminHandler) reject(c *gin.Context, news *models.News, reason string) error {
+	if news.Status != models.StatusPending {
+		return apierror.BadRequest("news_not_pending")
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data": news,
-		"meta": gin.H{
-			"total": len(news),
-		},
-	})
+	before := auditSnapshot(news)
+	news.Status = models.StatusRejected
+	news.RejectionReason = strings.TrimSpace(reason)
+	if err := h.newsRepo.Update(news); err != nil {
+		return apierror.Internal(err)
+	}
+	recordAudit(c, "news.reject", models.AuditTargetNews, news.ID, before, auditSnapshot(news))
+	return nil
+}
+
+// GetPendingNews gets all pending news for admin review (new articles only, not revisions)
+func (h *AdminHandler) GetPendingNews(c *gin.Context) {
+	h.listPending(c, false)
 }
 
 // GetPendingRevisions gets all pending revisions (edits) for admin review
 func (h *AdminHandler) GetPendingRevisions(c *gin.Context) {
-	var news []models.News
-	err := database.DB.Preload("Category").Preload("Author").Preload("Tags").
-		Where("status = ? AND revision_of IS NOT NULL", models.StatusPending).
-		Order("created_at DESC").
-		Find(&news).Error
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	h.listPending(c, true)
+}
+
+func (h *AdminHandler) listPending(c *gin.Context, revisions bool) {
+	params, ok := parseListParams(c, 50)
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data": news,
-		"meta": gin.H{
-			"total": len(news),
-		},
+	pending := models.StatusPending
+	news, total, next, err := h.newsRepo.FindAll(repository.NewsFilter{
+		Status:         &pending,
+		IsRevision:     &revisions,
+		Limit:          params.Limit,
+		Offset:         params.Offset(),
+		Cursor:         params.Cursor,
+		WithoutContent: true,
 	})
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	items := dto.NewNewsList(news)
+	for i := range items {
+		score := news[i].SimilarityScore
+		items[i].SimilarityScore = &score
+		items[i].SimilarMatches = news[i].SimilarMatches
+	}
+	if !revisions {
+		// Revisions are never rewarded
+		for i := range items {
+			suggestion, err := rewards.Suggest(&news[i])
+			if err != nil {
+				apierror.Respond(c, apierror.Internal(err))
+				return
+			}
+			items[i].SuggestedReward = suggestion
+		}
+	}
+
+	respondNewsItems(c, items, total, next, params)
 }
 
 // GetPendingCounts gets counts of pending new articles and pending revisions
@@ -269,13 +358,15 @@ func (h *AdminHandler) GetPendingCounts(c *gin.Context) {
 // GetStatistics gets dashboard statistics for admin
 func (h *AdminHandler) GetStatistics(c *gin.Context) {
 	var stats struct {
-		TotalPublished  int64         `json:"total_published"`
-		TotalPending    int64         `json:"total_pending"`
-		TotalDraft      int64         `json:"total_draft"`
-		TotalRejected   int64         `json:"total_rejected"`
-		TotalViews      int64         `json:"total_views"`
-		TotalPublishers int64         `json:"total_publishers"`
-		TopNews         []models.News `json:"top_news"`
+		TotalPublished   int64              `json:"total_published"`
+		TotalPending     int64              `json:"total_pending"`
+		TotalDraft       int64              `json:"total_draft"`
+		TotalRejected    int64              `json:"total_rejected"`
+		TotalUnpublished int64              `json:"total_unpublished"`
+		TotalArchived    int64              `json:"total_archived"`
+		TotalViews       int64              `json:"total_views"`
+		TotalPublishers  int64              `json:"total_publishers"`
+		TopNews          []dto.NewsListItem `json:"top_news"`
 	}
 
 	// Count by status
@@ -283,6 +374,8 @@ func (h *AdminHandler) GetStatistics(c *gin.Context) {
 	database.DB.Model(&models.News{}).Where("status = ?", models.StatusPending).Count(&stats.TotalPending)
 	database.DB.Model(&models.News{}).Where("status = ?", models.StatusDraft).Count(&stats.TotalDraft)
 	database.DB.Model(&models.News{}).Where("status = ?", models.StatusRejected).Count(&stats.TotalRejected)
+	database.DB.Model(&models.News{}).Where("status = ?", models.StatusUnpublished).Count(&stats.TotalUnpublished)
+	database.DB.Model(&models.News{}).Where("status = ?", models.StatusArchived).Count(&stats.TotalArchived)
 
 	// Total views
 	database.DB.Model(&models.News{}).Select("COALESCE(SUM(views), 0)").Scan(&stats.TotalViews)
@@ -295,7 +388,7 @@ func (h *AdminHandler) GetStatistics(c *gin.Context) {
 	database.DB.Where("status = ?", models.StatusPublished).
 		Preload("Category").Preload("Author").
 		Order("views DESC").Limit(5).Find(&allPublished)
-	stats.TopNews = allPublished
+	stats.TopNews = dto.NewNewsList(allPublished)
 
 	c.JSON(http.StatusOK, gin.H{"data": stats})
 }
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"reflect"
+
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// auditIgnoredFields are left out of diffs: timestamps change on every save
+// and preloaded relations are already covered by their *_id field
+var auditIgnoredFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"author":     true,
+	"category":   true,
+}
+
+// auditSnapshot captures the JSON fields of a record so it can be compared
+// after it is modified. Fields tagged json:"-" (password hashes, secrets)
+// are never captured.
+func auditSnapshot(v interface{}) map[string]interface{} {
+	if rv := reflect.ValueOf(v); !rv.IsValid() || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var snapshot map[string]interface{}
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil
+	}
+	for field := range auditIgnoredFields {
+		delete(snapshot, field)
+	}
+	return snapshot
+}
+
+// auditDiff returns the fields that differ between two snapshots
+func auditDiff(before, after map[string]interface{}) models.AuditChanges {
+	changes := models.AuditChanges{}
+	for field, value := range before {
+		if !reflect.DeepEqual(value, after[field]) {
+			changes[field] = models.AuditChange{Before: value, After: after[field]}
+		}
+	}
+	for field, value := range after {
+		if _, ok := before[field]; !ok && value != nil {
+			changes[field] = models.AuditChange{After: value}
+		}
+	}
+	return changes
+}
+
+// recordAudit writes an audit log entry for the authenticated user. before
+// and after are snapshots from auditSnapshot; pass nil for a created or
+// deleted record. Failures are logged and never fail the request.
+func recordAudit(c *gin.Context, action, targetType string, targetID uint, before, after map[string]interface{}) {
+	entry := &models.AuditLog{
+		ActorType:  userTypeString(c),
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Changes:    auditDiff(before, after),
+		IPAddress:  c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	}
+	if userID, ok := c.Get("user_id"); ok {
+		id := userID.(uint)
+		entry.ActorID = &id
+	}
+	if len(entry.UserAgent) > 500 {
+		entry.UserAgent = entry.UserAgent[:500]
+	}
+
+	if err := repository.NewAuditLogRepository().Create(entry); err != nil {
+		log.Printf("[Audit] Failed to record %s on %s %d: %v", action, targetType, targetID, err)
+	}
+}
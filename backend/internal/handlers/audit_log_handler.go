@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/dto"
+	"xinxun-news/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditLogHandler struct {
+	auditRepo *repository.AuditLogRepository
+}
+
+func NewAuditLogHandler() *AuditLogHandler {
+	return &AuditLogHandler{
+		auditRepo: repository.NewAuditLogRepository(),
+	}
+}
+
+// GetAuditLogs searches the audit log (admin only). Filters: actor_id,
+// actor (username), action, target_type, target_id, from and to (YYYY-MM-DD
+// or RFC 3339; a bare "to" date includes that whole day).
+func (h *AuditLogHandler) GetAuditLogs(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	params, ok := parseListParams(c, 50)
+	if !ok {
+		return
+	}
+
+	actorID, _ := strconv.ParseUint(c.Query("actor_id"), 10, 32)
+	targetID, _ := strconv.ParseUint(c.Query("target_id"), 10, 32)
+
+	filter := repository.AuditLogFilter{
+		ActorID:    uint(actorID),
+		Actor:      c.Query("actor"),
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		TargetID:   uint(targetID),
+		Limit:      params.Limit,
+		Offset:     params.Offset(),
+		Cursor:     params.Cursor,
+	}
+
+	if raw := c.Query("from"); raw != "" {
+		from, _, err := parseAuditDate(raw)
+		if err != nil {
+			apierror.Respond(c, apierror.BadRequest("query_param_invalid", "from"))
+			return
+		}
+		filter.From = &from
+	}
+	if raw := c.Query("to"); raw != "" {
+		to, dateOnly, err := parseAuditDate(raw)
+		if err != nil {
+			apierror.Respond(c, apierror.BadRequest("query_param_invalid", "to"))
+			return
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = &to
+	}
+
+	logs, total, next, err := h.auditRepo.FindAll(filter)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewAuditLogs(logs), "meta": listMeta(total, next, params)})
+}
+
+// parseAuditDate accepts YYYY-MM-DD or RFC 3339 and reports which one it got
+func parseAuditDate(value string) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
@@ -1,8 +1,15 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/dto"
+	"xinxun-news/internal/models"
 	"xinxun-news/internal/repository"
 	"xinxun-news/internal/services"
 
@@ -10,12 +17,18 @@ import (
 )
 
 type AuthHandler struct {
-	userRepo *repository.UserRepository
+	userRepo     *repository.UserRepository
+	tokenRepo    *repository.TokenRepository
+	recoveryRepo *repository.RecoveryCodeRepository
+	settingRepo  *repository.SettingRepository
 }
 
 func NewAuthHandler() *AuthHandler {
 	return &AuthHandler{
-		userRepo: repository.NewUserRepository(),
+		userRepo:     repository.NewUserRepository(),
+		tokenRepo:    repository.NewTokenRepository(),
+		recoveryRepo: repository.NewRecoveryCodeRepository(),
+		settingRepo:  repository.NewSettingRepository(),
 	}
 }
 
@@ -27,35 +40,269 @@ type LoginRequest struct {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	lockoutKey := "admin:" + strings.ToLower(req.Username)
+	if !checkLoginLockout(c, lockoutKey) {
 		return
 	}
 
 	user, err := h.userRepo.FindByUsername(req.Username)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Username atau password salah"})
+		recordLoginFailure(c, lockoutKey)
+		apierror.Respond(c, apierror.Unauthorized("invalid_credentials"))
 		return
 	}
 
 	if !services.CheckPasswordHash(req.Password, user.PasswordHash) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Username atau password salah"})
+		recordLoginFailure(c, lockoutKey)
+		apierror.Respond(c, apierror.Unauthorized("invalid_credentials"))
+		return
+	}
+	recordLoginSuccess(lockoutKey)
+
+	if !user.IsActive() {
+		apierror.Respond(c, apierror.Forbidden("account_disabled"))
+		return
+	}
+
+	// With 2FA enabled the password only earns a challenge token; the real
+	// JWT is issued by LoginTwoFactor
+	if user.TwoFactorEnabled {
+		challenge, err := services.GenerateChallengeToken(user.ID)
+		if err != nil {
+			apierror.Respond(c, apierror.New(http.StatusInternalServerError, "token_generation_failed").Wrap(err))
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"two_factor_required": true,
+			"challenge_token":     challenge,
+			"expires_in":          int(services.TwoFactorChallengeTTL.Seconds()),
+		})
+		return
+	}
+
+	h.respondLogin(c, user)
+}
+
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+// LoginTwoFactor completes a login started by Login using either a TOTP
+// code or a one-time recovery code
+func (h *AuthHandler) LoginTwoFactor(c *gin.Context) {
+	var req LoginTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+	if req.Code == "" && req.RecoveryCode == "" {
+		apierror.Respond(c, apierror.BadRequest("two_factor_code_required"))
 		return
 	}
 
-	token, err := services.GenerateToken(user.ID, string(user.UserType))
+	userID, err := services.ParseChallengeToken(req.ChallengeToken)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal menghasilkan token"})
+		apierror.Respond(c, apierror.Unauthorized("login_session_expired"))
+		return
+	}
+
+	lockoutKey := "2fa:" + strconv.FormatUint(uint64(userID), 10)
+	if !checkLoginLockout(c, lockoutKey) {
+		return
+	}
+
+	user, err := h.userRepo.FindByID(userID)
+	if err != nil || !user.TwoFactorEnabled {
+		apierror.Respond(c, apierror.Unauthorized("login_session_expired"))
+		return
+	}
+	if !user.IsActive() {
+		apierror.Respond(c, apierror.Forbidden("account_disabled"))
+		return
+	}
+
+	ok, err := verifySecondFactor(h.userRepo, h.recoveryRepo, user, req.Code, req.RecoveryCode)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	if !ok {
+		recordLoginFailure(c, lockoutKey)
+		apierror.Respond(c, apierror.Unauthorized("two_factor_code_invalid"))
+		return
+	}
+	recordLoginSuccess(lockoutKey)
+
+	h.respondLogin(c, user)
+}
+
+// respondLogin issues the session JWT once every login step has passed
+func (h *AuthHandler) respondLogin(c *gin.Context, user *models.User) {
+	flags := services.TokenFlags{
+		MustChangePassword: user.MustChangePassword,
+		MustSetupTwoFactor: twoFactorSetupRequired(h.settingRepo, user),
+	}
+
+	token, err := services.GenerateToken(user.ID, string(user.UserType), user.TokenVersion, flags)
+	if err != nil {
+		apierror.Respond(c, apierror.New(http.StatusInternalServerError, "token_generation_failed").Wrap(err))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"token": token,
-		"user": gin.H{
-			"id":        user.ID,
-			"username":  user.Username,
-			"name":      user.Name,
-			"email":     user.Email,
-			"user_type": user.UserType,
-		},
+		"token":                     token,
+		"must_change_password":      flags.MustChangePassword,
+		"two_factor_setup_required": flags.MustSetupTwoFactor,
+		"user":                      dto.NewPublisherUser(*user),
 	})
 }
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ForgotPassword emails a password reset link to an admin/editor account.
+// The response is the same whether or not the email exists.
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	user, err := h.userRepo.FindByEmail(req.Email)
+	if err == nil && user.UserType.IsStaff() && user.IsActive() {
+		if err := sendPasswordResetEmail(user); err != nil {
+			log.Printf("[Mail] Failed to send password reset email to user %d: %v", user.ID, err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Jika email terdaftar, link reset password telah dikirim"})
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+// ResetPassword sets a new password using a token from ForgotPassword
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	token, err := h.tokenRepo.FindValid(models.TokenPurposePasswordReset, services.HashOpaqueToken(req.Token))
+	if err != nil {
+		apierror.Respond(c, apierror.BadRequest("reset_token_invalid"))
+		return
+	}
+
+	user, err := h.userRepo.FindByID(token.UserID)
+	if err != nil {
+		apierror.Respond(c, apierror.BadRequest("reset_token_invalid"))
+		return
+	}
+
+	hashedPassword, err := services.HashPassword(req.Password)
+	if err != nil {
+		apierror.Respond(c, apierror.New(http.StatusInternalServerError, "password_hash_failed").Wrap(err))
+		return
+	}
+
+	// Sessions opened with the old password, possibly by whoever made the
+	// reset necessary, stop working
+	user.SetPassword(hashedPassword)
+	user.MustChangePassword = false
+	// Receiving the reset link proves ownership of the address
+	if user.EmailVerifiedAt == nil {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	if err := h.userRepo.Update(user); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	if err := h.tokenRepo.MarkUsed(token); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password berhasil direset. Silakan login kembali."})
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// VerifyEmail confirms an email address using a token from the verification email
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	token, err := h.tokenRepo.FindValid(models.TokenPurposeEmailVerification, services.HashOpaqueToken(req.Token))
+	if err != nil {
+		apierror.Respond(c, apierror.BadRequest("verification_token_invalid"))
+		return
+	}
+
+	user, err := h.userRepo.FindByID(token.UserID)
+	if err != nil {
+		apierror.Respond(c, apierror.BadRequest("verification_token_invalid"))
+		return
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	if err := h.userRepo.Update(user); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	if err := h.tokenRepo.MarkUsed(token); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email berhasil diverifikasi"})
+}
+
+// ResendVerificationEmail sends a new verification email to the current admin/editor
+func (h *AuthHandler) ResendVerificationEmail(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	user, err := h.userRepo.FindByID(userID.(uint))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("user_not_found"))
+		return
+	}
+
+	if !user.UserType.IsStaff() {
+		apierror.Respond(c, apierror.Forbidden("email_verification_staff_only"))
+		return
+	}
+
+	if user.EmailVerifiedAt != nil {
+		apierror.Respond(c, apierror.BadRequest("email_already_verified"))
+		return
+	}
+
+	if err := sendVerificationEmail(user); err != nil {
+		apierror.Respond(c, apierror.New(http.StatusInternalServerError, "verification_email_failed").Wrap(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verifikasi telah dikirim"})
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/dto"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthorHandler struct {
+	userRepo *repository.UserRepository
+	newsRepo *repository.NewsRepository
+}
+
+func NewAuthorHandler() *AuthorHandler {
+	return &AuthorHandler{
+		userRepo: repository.NewUserRepository(),
+		newsRepo: repository.NewNewsRepository(),
+	}
+}
+
+// GetAuthor returns the public profile of an author with their published
+// articles, paginated like GET /v1/news. Only active publishers with at
+// least one published article have a profile, so staff and suspended
+// accounts cannot be found by username.
+func (h *AuthorHandler) GetAuthor(c *gin.Context) {
+	params, ok := parseListParams(c, 10)
+	if !ok {
+		return
+	}
+	lang, ok := parseContentLanguage(c)
+	if !ok {
+		return
+	}
+
+	author, err := h.userRepo.FindByUsername(c.Param("username"))
+	if err != nil || author.UserType != models.UserTypePublisher || !author.IsActive() {
+		apierror.Respond(c, apierror.NotFound("author_not_found"))
+		return
+	}
+
+	articleCount, err := h.newsRepo.CountPublishedByAuthor(author.ID)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	if articleCount == 0 {
+		apierror.Respond(c, apierror.NotFound("author_not_found"))
+		return
+	}
+
+	published := models.StatusPublished
+	news, total, next, err := h.newsRepo.FindAll(repository.NewsFilter{
+		AuthorID:       author.ID,
+		Status:         &published,
+		Language:       lang,
+		Limit:          params.Limit,
+		Offset:         params.Offset(),
+		Cursor:         params.Cursor,
+		WithoutContent: true,
+	})
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	localizeNews(news, lang)
+	articles, meta, err := newsListPayload(news, total, next, params)
+	if err != nil {
+		apierror.Respond(c, apierror.BadRequest("pagination_invalid_fields", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"author":   dto.NewAuthorProfile(*author, articleCount),
+			"articles": articles,
+		},
+		"meta": meta,
+	})
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// syncXinxunBalance reconciles the ledger with the balance reported by
+// xinxun.us on login. Any difference is recorded as a sync_correction entry
+// instead of overwriting User.Balance, so the ledger explains every change.
+// Manual adjustments are never sent to xinxun.us, so they are left out of
+// the comparison and survive the sync.
+func syncXinxunBalance(user *models.User, remote float64) error {
+	balanceRepo := repository.NewBalanceRepository()
+
+	synced, err := balanceRepo.SyncedBalance(user.ID)
+	if err != nil {
+		return err
+	}
+
+	difference := models.MoneyFromFloat(remote) - synced
+	if difference == 0 {
+		current, err := balanceRepo.Balance(user.ID)
+		if err != nil {
+			return err
+		}
+		user.Balance = current
+		return nil
+	}
+
+	entry := &models.BalanceEntry{
+		UserID: user.ID,
+		Type:   models.BalanceEntrySyncCorrection,
+		Amount: difference,
+		Reason: "Sinkronisasi saldo dengan xinxun.us",
+	}
+	if err := balanceRepo.Record(entry); err != nil {
+		return err
+	}
+	user.Balance = entry.BalanceAfter
+	return nil
+}
+
+// recordBalanceAdjustment records a manual adjustment by the authenticated
+// admin and writes it to the audit log
+func recordBalanceAdjustment(c *gin.Context, userID uint, amount models.Money, reason string) (*models.BalanceEntry, error) {
+	entry := &models.BalanceEntry{
+		UserID: userID,
+		Type:   models.BalanceEntryAdjustment,
+		Amount: amount,
+		Reason: reason,
+	}
+	if actorID, ok := c.Get("user_id"); ok {
+		id := actorID.(uint)
+		entry.ActorID = &id
+	}
+
+	if err := repository.NewBalanceRepository().Record(entry); err != nil {
+		return nil, err
+	}
+
+	recordAudit(c, "publisher.balance_adjust", models.AuditTargetUser, userID,
+		map[string]interface{}{"balance": (entry.BalanceAfter - amount).Float64()},
+		map[string]interface{}{"balance": entry.BalanceAfter.Float64(), "reason": reason})
+	return entry, nil
+}
+
+// recordReward adds a paid article reward to the publisher's ledger along
+// with the rule that produced the amount
+func recordReward(c *gin.Context, news *models.News, rule *models.RewardSuggestion) error {
+	entry := &models.BalanceEntry{
+		UserID: news.AuthorID,
+		Type:   models.BalanceEntryReward,
+		Amount: rule.Amount,
+		NewsID: &news.ID,
+		Reason: "Reward artikel: " + news.Title,
+		Rule:   rule.JSON(),
+	}
+	if actorID, ok := c.Get("user_id"); ok {
+		id := actorID.(uint)
+		entry.ActorID = &id
+	}
+	return repository.NewBalanceRepository().Record(entry)
+}
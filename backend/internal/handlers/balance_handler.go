@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/dto"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BalanceHandler struct {
+	userRepo    *repository.UserRepository
+	balanceRepo *repository.BalanceRepository
+}
+
+func NewBalanceHandler() *BalanceHandler {
+	return &BalanceHandler{
+		userRepo:    repository.NewUserRepository(),
+		balanceRepo: repository.NewBalanceRepository(),
+	}
+}
+
+// GetEarnings returns the current publisher's balance, rewards per article
+// and ledger totals per month
+func (h *BalanceHandler) GetEarnings(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	if userTypeString(c) != string(models.UserTypePublisher) {
+		apierror.Respond(c, apierror.Forbidden("earnings_publisher_only"))
+		return
+	}
+
+	balance, err := h.balanceRepo.Balance(userID.(uint))
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	articles, err := h.balanceRepo.RewardsByArticle(userID.(uint))
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	months, err := h.balanceRepo.TotalsByMonth(userID.(uint))
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	var totalRewards models.Money
+	for _, article := range articles {
+		totalRewards += article.Amount
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"balance":       balance,
+			"total_rewards": totalRewards,
+			"articles":      articles,
+			"monthly":       months,
+		},
+	})
+}
+
+// GetLedger lists a publisher's balance movements (admin only), or the
+// current publisher's own movements
+func (h *BalanceHandler) GetLedger(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	targetID := userID.(uint)
+
+	if c.Param("id") != "" {
+		if !requireAdmin(c) {
+			return
+		}
+		id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+		targetID = uint(id)
+	}
+
+	params, ok := parseListParams(c, 50)
+	if !ok {
+		return
+	}
+
+	entries, total, next, err := h.balanceRepo.FindByUser(targetID, params.Limit, params.Offset(), params.Cursor)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": dto.NewBalanceEntries(entries),
+		"meta": listMeta(total, next, params),
+	})
+}
+
+type BalanceAdjustmentRequest struct {
+	Amount models.Money `json:"amount" binding:"required"` // Positif menambah, negatif mengurangi saldo
+	Reason string       `json:"reason" binding:"required"`
+}
+
+// AdjustBalance records a manual balance adjustment for a publisher (admin only)
+func (h *BalanceHandler) AdjustBalance(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	publisher, err := h.userRepo.FindByID(uint(id))
+	if err != nil || publisher.UserType != models.UserTypePublisher {
+		apierror.Respond(c, apierror.NotFound("publisher_not_found"))
+		return
+	}
+
+	var req BalanceAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		apierror.Respond(c, apierror.BadRequest("adjustment_reason_required"))
+		return
+	}
+	balance, err := h.balanceRepo.Balance(publisher.ID)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	if balance+req.Amount < 0 {
+		apierror.Respond(c, apierror.BadRequest("balance_negative"))
+		return
+	}
+
+	entry, err := recordBalanceAdjustment(c, publisher.ID, req.Amount, reason)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Saldo berhasil disesuaikan",
+		"data":    dto.NewBalanceEntry(*entry),
+	})
+}
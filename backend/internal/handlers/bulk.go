@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IDListRequest is the body of the reorder and bulk delete endpoints
+type IDListRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1"`
+}
+
+// BlockedItem is a record a bulk operation had to skip, with the reason
+type BlockedItem struct {
+	ID      uint   `json:"id"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func newBlockedItem(c *gin.Context, id uint, code string) BlockedItem {
+	lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	return BlockedItem{ID: id, Code: code, Message: i18n.T(lang, code)}
+}
+
+// validOrderIDs checks a reorder list against the ids that exist. It
+// responds and returns false for duplicate or unknown ids.
+func validOrderIDs(c *gin.Context, ids []uint, existing map[uint]bool) bool {
+	seen := make(map[uint]bool, len(ids))
+	var unknown []uint
+	for _, id := range ids {
+		if seen[id] {
+			apierror.Respond(c, apierror.BadRequest("order_ids_duplicate").With("id", id))
+			return false
+		}
+		seen[id] = true
+		if !existing[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) > 0 {
+		apierror.Respond(c, apierror.BadRequest("order_ids_unknown").With("ids", unknown))
+		return false
+	}
+	return true
+}
@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
+	"xinxun-news/internal/apierror"
 	"xinxun-news/internal/database"
+	"xinxun-news/internal/dto"
 	"xinxun-news/internal/models"
 	"xinxun-news/internal/repository"
 
@@ -22,6 +25,9 @@ func NewCategoryHandler() *CategoryHandler {
 	}
 }
 
+// GetCategories returns every category in display order. The list is not
+// paginated: it is small, ordered by position rather than creation time,
+// and the editor and the tree need all of it.
 func (h *CategoryHandler) GetCategories(c *gin.Context) {
 	// All users (public, publisher, admin) can see all categories
 	// The restriction is only on publishing (handled in news creation/update)
@@ -30,46 +36,142 @@ func (h *CategoryHandler) GetCategories(c *gin.Context) {
 	categories, err = h.categoryRepo.FindAll()
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
+	lang, ok := parseContentLanguage(c)
+	if !ok {
+		return
+	}
+	localizeCategories(categories, lang)
+
+	if models.UserType(userTypeString(c)).IsStaff() {
+		c.JSON(http.StatusOK, gin.H{"data": dto.NewAdminCategories(categories)})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewPublicCategories(categories)})
+}
 
-	c.JSON(http.StatusOK, gin.H{"data": categories})
+// GetCategoryTree returns categories nested under their parents. With
+// ?root=<slug> only that category and its subcategories are returned.
+func (h *CategoryHandler) GetCategoryTree(c *gin.Context) {
+	lang, ok := parseContentLanguage(c)
+	if !ok {
+		return
+	}
+
+	categories, err := h.categoryRepo.FindAll()
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	if rootSlug := c.Query("root"); rootSlug != "" {
+		root, err := h.categoryRepo.FindBySlug(rootSlug)
+		if err != nil {
+			apierror.Respond(c, apierror.NotFound("category_not_found"))
+			return
+		}
+		ids, err := h.categoryRepo.FindDescendantIDs(root.ID)
+		if err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+		inTree := make(map[uint]bool, len(ids))
+		for _, id := range ids {
+			inTree[id] = true
+		}
+		subtree := make([]models.Category, 0, len(ids))
+		for _, category := range categories {
+			if inTree[category.ID] {
+				subtree = append(subtree, category)
+			}
+		}
+		categories = subtree
+	}
+
+	localizeCategories(categories, lang)
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewCategoryTree(categories)})
+}
+
+// validParent checks that parentID can become the parent of a category.
+// categoryID is 0 for new categories. Moving a category below itself or
+// one of its own subcategories would create a cycle and is rejected. It
+// responds and returns false when the parent is not allowed.
+func (h *CategoryHandler) validParent(c *gin.Context, categoryID, parentID uint) bool {
+	if _, err := h.categoryRepo.FindByID(parentID); err != nil {
+		apierror.Respond(c, apierror.BadRequest("category_parent_not_found"))
+		return false
+	}
+	if categoryID == 0 {
+		return true
+	}
+
+	descendants, err := h.categoryRepo.FindDescendantIDs(categoryID)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return false
+	}
+	for _, id := range descendants {
+		if id == parentID {
+			apierror.Respond(c, apierror.BadRequest("category_parent_cycle"))
+			return false
+		}
+	}
+	return true
 }
 
 type CreateCategoryRequest struct {
-	Name        string `json:"name" binding:"required"`
-	IsAdminOnly bool   `json:"is_admin_only"`
-	Order       int    `json:"order"` // Urutan tampilan (optional, default akan di-set otomatis)
+	Name        string                `json:"name" binding:"required"`
+	Names       models.LocalizedNames `json:"names"`     // Nama per bahasa (optional), mis. {"en": "Technology"}
+	ParentID    *uint                 `json:"parent_id"` // Kategori induk (optional)
+	IsAdminOnly bool                  `json:"is_admin_only"`
+	Order       int                   `json:"order"` // Urutan tampilan (optional, default akan di-set otomatis)
 }
 
 func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 	var req CreateCategoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	if !validLocalizedNames(c, req.Names, "category_names_unsupported") {
+		return
+	}
+	if req.ParentID != nil && *req.ParentID == 0 {
+		req.ParentID = nil
+	}
+	if req.ParentID != nil && !h.validParent(c, 0, *req.ParentID) {
 		return
 	}
 
 	categorySlug := slug.Make(req.Name)
 	category := &models.Category{
 		Name:        req.Name,
+		Names:       req.Names,
 		Slug:        categorySlug,
+		ParentID:    req.ParentID,
 		IsAdminOnly: req.IsAdminOnly,
 		Order:       req.Order,
 	}
 
 	if err := h.categoryRepo.Create(category); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"data": category})
+	recordAudit(c, "category.create", models.AuditTargetCategory, category.ID, nil, auditSnapshot(category))
+
+	c.JSON(http.StatusCreated, gin.H{"data": dto.NewAdminCategory(*category)})
 }
 
 type UpdateCategoryRequest struct {
-	Name        string `json:"name"`
-	IsAdminOnly *bool  `json:"is_admin_only"`
-	Order       *int   `json:"order"` // Urutan tampilan (optional)
+	Name        string                `json:"name"`
+	Names       models.LocalizedNames `json:"names"`     // Menggantikan semua nama per bahasa bila diisi
+	ParentID    *uint                 `json:"parent_id"` // Pindah ke kategori induk lain, 0 untuk kategori utama
+	IsAdminOnly *bool                 `json:"is_admin_only"`
+	Order       *int                  `json:"order"` // Urutan tampilan (optional)
 }
 
 func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
@@ -77,21 +179,40 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 
 	category, err := h.categoryRepo.FindByID(uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Kategori tidak ditemukan"})
+		apierror.Respond(c, apierror.NotFound("category_not_found"))
 		return
 	}
 
 	var req UpdateCategoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Bind(err))
 		return
 	}
+	before := auditSnapshot(category)
 
 	if req.Name != "" {
 		category.Name = req.Name
 		category.Slug = slug.Make(req.Name)
 	}
 
+	if req.Names != nil {
+		if !validLocalizedNames(c, req.Names, "category_names_unsupported") {
+			return
+		}
+		category.Names = req.Names
+	}
+
+	if req.ParentID != nil {
+		if *req.ParentID == 0 {
+			category.ParentID = nil
+		} else {
+			if !h.validParent(c, category.ID, *req.ParentID) {
+				return
+			}
+			category.ParentID = req.ParentID
+		}
+	}
+
 	if req.IsAdminOnly != nil {
 		category.IsAdminOnly = *req.IsAdminOnly
 	}
@@ -101,28 +222,205 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 	}
 
 	if err := h.categoryRepo.Update(category); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": category})
+	recordAudit(c, "category.update", models.AuditTargetCategory, category.ID, before, auditSnapshot(category))
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewAdminCategory(*category)})
 }
 
 func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 
-	// Check if category has news
+	// Check if category has news, trashed ones included: they can still be
+	// restored into it
 	var count int64
-	database.DB.Model(&models.News{}).Where("category_id = ?", id).Count(&count)
+	database.DB.Unscoped().Model(&models.News{}).Where("category_id = ?", id).Count(&count)
 	if count > 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Tidak dapat menghapus kategori dengan artikel yang ada"})
+		apierror.Respond(c, apierror.BadRequest("category_in_use"))
 		return
 	}
 
+	// Subcategories have to be moved or deleted first
+	var children int64
+	database.DB.Model(&models.Category{}).Where("parent_id = ?", id).Count(&children)
+	if children > 0 {
+		apierror.Respond(c, apierror.BadRequest("category_has_children"))
+		return
+	}
+
+	var before map[string]interface{}
+	if category, err := h.categoryRepo.FindByID(uint(id)); err == nil {
+		before = auditSnapshot(category)
+	}
+
 	if err := h.categoryRepo.Delete(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
+	recordAudit(c, "category.delete", models.AuditTargetCategory, uint(id), before, nil)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Kategori berhasil dihapus"})
 }
+
+// ReorderCategories sets the display order of sibling categories from an
+// ordered list of ids, which must all share one parent. Siblings left out
+// of the list keep their relative order after the listed ones (admin only).
+func (h *CategoryHandler) ReorderCategories(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req IDListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	categories, err := h.categoryRepo.FindAll()
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	previous := make(map[uint]int, len(categories))
+	parents := make(map[uint]*uint, len(categories))
+	for _, category := range categories {
+		previous[category.ID] = category.Order
+		parents[category.ID] = category.ParentID
+	}
+	existing := make(map[uint]bool, len(categories))
+	for id := range previous {
+		existing[id] = true
+	}
+	if !validOrderIDs(c, req.IDs, existing) {
+		return
+	}
+
+	parentID := parents[req.IDs[0]]
+	for _, id := range req.IDs[1:] {
+		if !sameParent(parents[id], parentID) {
+			apierror.Respond(c, apierror.BadRequest("order_ids_mixed_parents").With("id", id))
+			return
+		}
+	}
+
+	if err := h.categoryRepo.Reorder(parentID, req.IDs); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	categories, err = h.categoryRepo.FindAll()
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	for _, category := range categories {
+		if category.Order != previous[category.ID] {
+			recordAudit(c, "category.reorder", models.AuditTargetCategory, category.ID,
+				map[string]interface{}{"order": previous[category.ID]},
+				map[string]interface{}{"order": category.Order})
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewAdminCategories(categories)})
+}
+
+// BulkDeleteCategories deletes several categories at once. Categories that
+// still have articles, or subcategories that are not deleted with them, are
+// skipped and reported in "blocked" (admin only).
+func (h *CategoryHandler) BulkDeleteCategories(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req IDListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	categories, err := h.categoryRepo.FindAll()
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	byID := make(map[uint]models.Category, len(categories))
+	children := make(map[uint][]uint)
+	for _, category := range categories {
+		byID[category.ID] = category
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+		}
+	}
+
+	blocked := map[uint]string{}
+	deleting := map[uint]bool{}
+	var ids []uint
+	for _, id := range req.IDs {
+		if _, ok := blocked[id]; ok || deleting[id] {
+			continue
+		}
+		ids = append(ids, id)
+		if _, ok := byID[id]; !ok {
+			blocked[id] = "category_not_found"
+			continue
+		}
+		var count int64
+		database.DB.Unscoped().Model(&models.News{}).Where("category_id = ?", id).Count(&count)
+		if count > 0 {
+			blocked[id] = "category_in_use"
+			continue
+		}
+		deleting[id] = true
+	}
+
+	// A category can only go when all of its subcategories go with it
+	for changed := true; changed; {
+		changed = false
+		for id := range deleting {
+			for _, child := range children[id] {
+				if !deleting[child] {
+					delete(deleting, id)
+					blocked[id] = "category_has_children"
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	deleted := []uint{}
+	blockedItems := []BlockedItem{}
+	for _, id := range ids {
+		if code, ok := blocked[id]; ok {
+			blockedItems = append(blockedItems, newBlockedItem(c, id, code))
+			continue
+		}
+		if err := h.categoryRepo.Delete(id); err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+		category := byID[id]
+		recordAudit(c, "category.delete", models.AuditTargetCategory, id, auditSnapshot(&category), nil)
+		deleted = append(deleted, id)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": fmt.Sprintf("%d kategori berhasil dihapus", len(deleted)),
+		"data": gin.H{
+			"deleted": deleted,
+			"blocked": blockedItems,
+		},
+	})
+}
+
+// sameParent reports whether two parent ids point to the same parent,
+// nil being the top level
+func sameParent(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
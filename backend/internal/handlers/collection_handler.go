@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/dto"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gosimple/slug"
+)
+
+// maxCollectionItems is the most articles a collection can hold
+const maxCollectionItems = 50
+
+type CollectionHandler struct {
+	collectionRepo *repository.CollectionRepository
+	newsRepo       *repository.NewsRepository
+}
+
+func NewCollectionHandler() *CollectionHandler {
+	return &CollectionHandler{
+		collectionRepo: repository.NewCollectionRepository(),
+		newsRepo:       repository.NewNewsRepository(),
+	}
+}
+
+// GetCollection returns a collection with its published articles whose
+// display window is open, in editor order
+func (h *CollectionHandler) GetCollection(c *gin.Context) {
+	lang, ok := parseContentLanguage(c)
+	if !ok {
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > maxCollectionItems {
+		apierror.Respond(c, apierror.BadRequest("query_param_invalid", "limit"))
+		return
+	}
+
+	collection, err := h.collectionRepo.FindBySlug(c.Param("slug"))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("collection_not_found"))
+		return
+	}
+
+	news, err := h.collectionRepo.FindActiveNews(collection.ID, lang, limit, time.Now())
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	localizeNews(news, lang)
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewPublicCollection(*collection, news)})
+}
+
+// GetCollections lists the collections without their items (staff only)
+func (h *CollectionHandler) GetCollections(c *gin.Context) {
+	if !requireStaff(c) {
+		return
+	}
+
+	collections, err := h.collectionRepo.FindAll()
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewAdminCollections(collections)})
+}
+
+// GetCollectionByID returns a collection with every item, including the
+// ones outside their display window (staff only)
+func (h *CollectionHandler) GetCollectionByID(c *gin.Context) {
+	if !requireStaff(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	collection, err := h.collectionRepo.FindByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("collection_not_found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewAdminCollection(*collection)})
+}
+
+type CollectionRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Slug        string `json:"slug"` // Optional, derived from the name when empty
+	Description string `json:"description"`
+}
+
+// collectionSlug returns the slug a collection request asks for. It
+// responds and returns false when the slug is empty or taken by another
+// collection.
+func (h *CollectionHandler) collectionSlug(c *gin.Context, req CollectionRequest, id uint) (string, bool) {
+	collectionSlug := req.Slug
+	if collectionSlug == "" {
+		collectionSlug = req.Name
+	}
+	collectionSlug = slug.Make(collectionSlug)
+	if collectionSlug == "" {
+		apierror.Respond(c, apierror.BadRequest("collection_slug_invalid"))
+		return "", false
+	}
+	if existing, err := h.collectionRepo.FindBySlug(collectionSlug); err == nil && existing.ID != id {
+		apierror.Respond(c, apierror.Conflict("collection_slug_exists", collectionSlug))
+		return "", false
+	}
+	return collectionSlug, true
+}
+
+// CreateCollection adds an empty collection (staff only)
+func (h *CollectionHandler) CreateCollection(c *gin.Context) {
+	if !requireStaff(c) {
+		return
+	}
+
+	var req CollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+	collectionSlug, ok := h.collectionSlug(c, req, 0)
+	if !ok {
+		return
+	}
+
+	collection := &models.Collection{
+		Name:        req.Name,
+		Slug:        collectionSlug,
+		Description: req.Description,
+	}
+	if err := h.collectionRepo.Create(collection); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "collection.create", models.AuditTargetCollection, collection.ID, nil, auditSnapshot(collection))
+
+	c.JSON(http.StatusCreated, gin.H{"data": dto.NewAdminCollection(*collection)})
+}
+
+// UpdateCollection renames a collection (staff only). Changing the slug
+// changes the public URL of the collection.
+func (h *CollectionHandler) UpdateCollection(c *gin.Context) {
+	if !requireStaff(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	collection, err := h.collectionRepo.FindByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("collection_not_found"))
+		return
+	}
+
+	var req CollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+	collectionSlug, ok := h.collectionSlug(c, req, collection.ID)
+	if !ok {
+		return
+	}
+	before := auditSnapshot(collection)
+
+	collection.Name = req.Name
+	collection.Slug = collectionSlug
+	collection.Description = req.Description
+	if err := h.collectionRepo.Update(collection); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "collection.update", models.AuditTargetCollection, collection.ID, before, auditSnapshot(collection))
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewAdminCollection(*collection)})
+}
+
+// DeleteCollection removes a collection and its items; the articles stay
+// (staff only)
+func (h *CollectionHandler) DeleteCollection(c *gin.Context) {
+	if !requireStaff(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	collection, err := h.collectionRepo.FindByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("collection_not_found"))
+		return
+	}
+
+	if err := h.collectionRepo.Delete(collection.ID); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "collection.delete", models.AuditTargetCollection, collection.ID, auditSnapshot(collection), nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Koleksi berhasil dihapus"})
+}
+
+type CollectionItemRequest struct {
+	NewsID    uint       `json:"news_id" binding:"required"`
+	StartsAt  *time.Time `json:"starts_at"`  // null = shown right away
+	ExpiresAt *time.Time `json:"expires_at"` // null = shown until removed
+}
+
+type SetCollectionItemsRequest struct {
+	// Items in display order; an empty list clears the collection
+	Items []CollectionItemRequest `json:"items" binding:"max=50,dive"`
+}
+
+// SetCollectionItems replaces the articles of a collection (staff only).
+// Unpublished articles may be scheduled; they only show once published.
+func (h *CollectionHandler) SetCollectionItems(c *gin.Context) {
+	if !requireStaff(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	collection, err := h.collectionRepo.FindByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("collection_not_found"))
+		return
+	}
+
+	var req SetCollectionItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	ids := make([]uint, 0, len(req.Items))
+	for _, item := range req.Items {
+		ids = append(ids, item.NewsID)
+	}
+	found := map[uint]models.News{}
+	if len(ids) > 0 {
+		news, err := h.newsRepo.FindByIDs(ids)
+		if err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+		for _, item := range news {
+			found[item.ID] = item
+		}
+	}
+
+	var details []apierror.FieldError
+	seen := map[uint]bool{}
+	items := make([]models.CollectionItem, 0, len(req.Items))
+	for i, item := range req.Items {
+		news, ok := found[item.NewsID]
+		switch {
+		case !ok || news.RevisionOf != nil:
+			details = append(details, apierror.Field(fmt.Sprintf("items[%d].news_id", i), "news_not_found"))
+		case seen[item.NewsID]:
+			details = append(details, apierror.Field(fmt.Sprintf("items[%d].news_id", i), "collection_item_duplicate"))
+		case item.StartsAt != nil && item.ExpiresAt != nil && !item.ExpiresAt.After(*item.StartsAt):
+			details = append(details, apierror.Field(fmt.Sprintf("items[%d].expires_at", i), "collection_item_window_invalid"))
+		}
+		seen[item.NewsID] = true
+		items = append(items, models.CollectionItem{
+			NewsID:    item.NewsID,
+			StartsAt:  item.StartsAt,
+			ExpiresAt: item.ExpiresAt,
+		})
+	}
+	if len(details) > 0 {
+		apierror.Respond(c, apierror.BadRequest("collection_items_invalid").WithDetails(details...))
+		return
+	}
+	before := auditSnapshot(collection)
+
+	if err := h.collectionRepo.ReplaceItems(collection.ID, items); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	collection, err = h.collectionRepo.FindByID(collection.ID)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "collection.items", models.AuditTargetCollection, collection.ID, before, auditSnapshot(collection))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Isi koleksi berhasil diperbarui",
+		"data":    dto.NewAdminCollection(*collection),
+	})
+}
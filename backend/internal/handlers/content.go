@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/blocks"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/sanitize"
+	"xinxun-news/internal/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// resolveContent turns submitted article content into what is stored.
+// Blocks take precedence: they are validated and rendered to HTML. Raw HTML
+// is sanitized and stored without blocks. Errors are API errors; a problem
+// with one block is reported as a detail on blocks[i].
+func resolveContent(content string, contentBlocks models.ContentBlocks) (string, models.ContentBlocks, error) {
+	if len(contentBlocks) > 0 {
+		normalized, err := blocks.Normalize(contentBlocks)
+		var blockErr *blocks.Error
+		switch {
+		case errors.As(err, &blockErr) && blockErr.Block > 0:
+			field := fmt.Sprintf("blocks[%d]", blockErr.Block-1)
+			return "", nil, apierror.BadRequest("content_blocks_invalid").
+				WithDetails(apierror.Field(field, blockErr.Code, blockErr.Args...))
+		case errors.As(err, &blockErr):
+			return "", nil, apierror.BadRequest(blockErr.Code, blockErr.Args...)
+		case err != nil:
+			return "", nil, apierror.Internal(err)
+		}
+		return blocks.HTML(normalized), normalized, nil
+	}
+
+	content = sanitize.Content(content)
+	if strings.TrimSpace(content) == "" {
+		return "", nil, apierror.BadRequest("content_required")
+	}
+	return content, nil, nil
+}
+
+// validateArticle checks an article against the content rules and responds
+// 400 with every violation when it breaks any. It returns false when the
+// request was rejected.
+func validateArticle(c *gin.Context, article validation.Article) bool {
+	violations := validation.Validate(article, validation.LoadRules())
+	if len(violations) == 0 {
+		return true
+	}
+	apiErr := apierror.BadRequest("content_rules_violated")
+	for _, violation := range violations {
+		apiErr.WithDetails(apierror.Field(violation.Field, "content_rule_"+violation.Rule, violation.Args...))
+	}
+	apierror.Respond(c, apiErr)
+	return false
+}
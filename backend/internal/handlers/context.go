@@ -0,0 +1,11 @@
+package handlers
+
+import "github.com/gin-gonic/gin"
+
+// userTypeString returns the user_type set by AuthMiddleware, or an empty
+// string for anonymous requests
+func userTypeString(c *gin.Context) string {
+	userType, _ := c.Get("user_type")
+	value, _ := userType.(string)
+	return value
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"log"
+	"time"
+
+	"xinxun-news/internal/middleware"
+	"xinxun-news/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ipLockout tolerates more failures than the per-account lockout because
+// several users can share an IP
+var ipLockout = ratelimit.Lockout{
+	Threshold: 20,
+	BaseLock:  time.Minute,
+	MaxLock:   time.Hour,
+	Window:    time.Hour,
+}
+
+// checkLoginLockout writes a 429 response and returns false when either the
+// account or the client IP is locked out after repeated failures
+func checkLoginLockout(c *gin.Context, accountKey string) bool {
+	for _, check := range []struct {
+		lockout ratelimit.Lockout
+		key     string
+	}{
+		{ratelimit.DefaultLockout, accountKey},
+		{ipLockout, "ip:" + c.ClientIP()},
+	} {
+		remaining, err := check.lockout.Check(check.key)
+		if err != nil {
+			log.Printf("[Lockout] store error for %s: %v", check.key, err)
+			continue
+		}
+		if remaining > 0 {
+			middleware.TooManyRequests(c, remaining)
+			return false
+		}
+	}
+	return true
+}
+
+// recordLoginFailure counts a failed attempt against the account and the client IP
+func recordLoginFailure(c *gin.Context, accountKey string) {
+	if _, err := ratelimit.DefaultLockout.Fail(accountKey); err != nil {
+		log.Printf("[Lockout] store error for %s: %v", accountKey, err)
+	}
+	if _, err := ipLockout.Fail("ip:" + c.ClientIP()); err != nil {
+		log.Printf("[Lockout] store error for ip %s: %v", c.ClientIP(), err)
+	}
+}
+
+// recordLoginSuccess clears the account failures. IP failures are kept so a
+// valid account cannot be used to reset them.
+func recordLoginSuccess(accountKey string) {
+	if err := ratelimit.DefaultLockout.Succeed(accountKey); err != nil {
+		log.Printf("[Lockout] store error for %s: %v", accountKey, err)
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/dto"
+	"xinxun-news/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxPinnedNews is the most pinned articles shown above the public news
+// list
+const maxPinnedNews = 10
+
+type UpdateCurationRequest struct {
+	IsPinned      bool       `json:"is_pinned"`
+	PinnedUntil   *time.Time `json:"pinned_until"` // null = pinned until unpinned by hand
+	IsFeatured    bool       `json:"is_featured"`
+	FeaturedOrder int        `json:"featured_order"` // Lower comes first in the slider
+	FeaturedUntil *time.Time `json:"featured_until"` // null = featured until unfeatured by hand
+}
+
+// UpdateCuration pins an article to the top of the public news list and/or
+// features it in the slider, optionally until a given time
+func (h *AdminHandler) UpdateCuration(c *gin.Context) {
+	if !requireStaff(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	news, err := h.newsRepo.FindByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("news_not_found"))
+		return
+	}
+	if news.RevisionOf != nil {
+		apierror.Respond(c, apierror.BadRequest("news_revision_not_allowed"))
+		return
+	}
+
+	var req UpdateCurationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	now := time.Now()
+	var details []apierror.FieldError
+	if req.IsPinned && req.PinnedUntil != nil && !req.PinnedUntil.After(now) {
+		details = append(details, apierror.Field("pinned_until", "curation_until_past"))
+	}
+	if req.IsFeatured && req.FeaturedUntil != nil && !req.FeaturedUntil.After(now) {
+		details = append(details, apierror.Field("featured_until", "curation_until_past"))
+	}
+	if len(details) > 0 {
+		apierror.Respond(c, apierror.BadRequest("curation_invalid").WithDetails(details...))
+		return
+	}
+	before := auditSnapshot(news)
+
+	news.IsPinned = req.IsPinned
+	news.PinnedUntil = nil
+	if req.IsPinned {
+		news.PinnedUntil = req.PinnedUntil
+	}
+	news.IsFeatured = req.IsFeatured
+	news.FeaturedOrder = 0
+	news.FeaturedUntil = nil
+	if req.IsFeatured {
+		news.FeaturedOrder = req.FeaturedOrder
+		news.FeaturedUntil = req.FeaturedUntil
+	}
+
+	if err := h.newsRepo.UpdateCuration(news); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "news.curation", models.AuditTargetNews, news.ID, before, auditSnapshot(news))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Kurasi artikel berhasil diperbarui",
+		"data":    dto.NewAdminNews(*news),
+	})
+}
@@ -3,11 +3,15 @@ package handlers
 import (
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/blocks"
+	"xinxun-news/internal/dto"
+	"xinxun-news/internal/i18n"
 	"xinxun-news/internal/models"
 	"xinxun-news/internal/repository"
+	"xinxun-news/internal/validation"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gosimple/slug"
@@ -26,23 +30,15 @@ func NewNewsHandler() *NewsHandler {
 }
 
 func (h *NewsHandler) GetNews(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	search := c.Query("q")
-	category := c.Query("category")
-
-	if limit > 100 {
-		limit = 100
-	}
-	if limit < 1 {
-		limit = 10
+	params, ok := parseListParams(c, 10)
+	if !ok {
+		return
 	}
-	if page < 1 {
-		page = 1
+	lang, ok := parseContentLanguage(c)
+	if !ok {
+		return
 	}
 
-	offset := (page - 1) * limit
-
 	// For public, only show published. For admin/publisher, show all statuses
 	var status *models.NewsStatus
 	_, exists := c.Get("user_id")
@@ -53,24 +49,99 @@ func (h *NewsHandler) GetNews(c *gin.Context) {
 	}
 	// If user is authenticated (admin/publisher), status is nil = show all
 
-	news, total, err := h.newsRepo.FindAll(limit, offset, search, category, status)
+	filter := repository.NewsFilter{
+		Search:         c.Query("q"),
+		Category:       c.Query("category"),
+		Author:         c.Query("author"),
+		Status:         status,
+		Language:       lang,
+		Limit:          params.Limit,
+		Offset:         params.Offset(),
+		Cursor:         params.Cursor,
+		WithoutContent: true,
+	}
+
+	// Public listings show pinned articles above the first page instead of
+	// at their date. Search results keep plain date order.
+	find := h.newsRepo.FindAll
+	if status != nil && filter.Search == "" {
+		find = h.findWithPins
+	}
+
+	news, total, next, err := find(filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data": news,
-		"meta": gin.H{
-			"total": total,
-			"page":  page,
-			"limit": limit,
-			"pages": (int(total) + limit - 1) / limit,
-		},
-	})
+	respondNewsList(c, news, total, next, params)
+}
+
+// findWithPins pages through a list that starts with the newest
+// maxPinnedNews active pins, followed by every other article by date (pins
+// past the cap included). Pages keep their size and report the same total.
+// A cursor pointing at one of the leading pins continues after it.
+func (h *NewsHandler) findWithPins(filter repository.NewsFilter) ([]models.News, int64, *repository.Cursor, error) {
+	onlyPinned := true
+	pinnedFilter := filter
+	pinnedFilter.Pinned = &onlyPinned
+	pinnedFilter.Limit = maxPinnedNews
+	pinnedFilter.Offset = 0
+	pinnedFilter.Cursor = nil
+	pinned, _, _, err := h.newsRepo.FindAll(pinnedFilter)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	// The leading pins still to show on this page
+	var head []models.News
+	rest := filter
+	rest.ExcludeIDs = make([]uint, 0, len(pinned))
+	for _, item := range pinned {
+		rest.ExcludeIDs = append(rest.ExcludeIDs, item.ID)
+	}
+	if filter.Cursor != nil {
+		for i, item := range pinned {
+			if item.ID == filter.Cursor.ID {
+				head = pinned[i+1:]
+				rest.Cursor = nil
+				rest.Offset = 0
+				break
+			}
+		}
+	} else if filter.Offset < len(pinned) {
+		head = pinned[filter.Offset:]
+		rest.Offset = 0
+	} else {
+		rest.Offset = filter.Offset - len(pinned)
+	}
+	if len(head) > filter.Limit {
+		head = head[:filter.Limit]
+	}
+
+	rest.Limit = filter.Limit - len(head)
+	if rest.Limit == 0 {
+		// The page is all pins; only the count of the rest is needed
+		rest.Limit = 1
+	}
+	news, total, next, err := h.newsRepo.FindAll(rest)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	total += int64(len(pinned))
+
+	if len(head) == filter.Limit {
+		news, next = nil, nil
+		last := head[len(head)-1]
+		if last.ID != pinned[len(pinned)-1].ID || total > int64(len(pinned)) {
+			next = &repository.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		}
+	}
+	return append(head, news...), total, next, nil
 }
 
-// GetFeaturedNews gets top viewed news for featured slider
+// GetFeaturedNews gets the news for the featured slider: the articles
+// editors featured, by featured_order, topped up with the most viewed ones
 func (h *NewsHandler) GetFeaturedNews(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "5"))
 	if limit < 3 {
@@ -80,24 +151,38 @@ func (h *NewsHandler) GetFeaturedNews(c *gin.Context) {
 		limit = 5
 	}
 
-	news, err := h.newsRepo.FindTopViews(limit)
+	lang, ok := parseContentLanguage(c)
+	if !ok {
+		return
+	}
+
+	news, err := h.newsRepo.FindFeatured(limit, lang)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
+	if len(news) < limit {
+		featuredIDs := make([]uint, 0, len(news))
+		for _, item := range news {
+			featuredIDs = append(featuredIDs, item.ID)
+		}
+		topViews, err := h.newsRepo.FindTopViews(limit-len(news), lang, featuredIDs)
+		if err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+		news = append(news, topViews...)
+	}
+	localizeNews(news, lang)
 
-	c.JSON(http.StatusOK, gin.H{"data": news})
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewNewsList(news)})
 }
 
 // GetNewestNews gets 3 newest published news for xinxun.us integration
 func (h *NewsHandler) GetNewestNews(c *gin.Context) {
 	news, err := h.newsRepo.FindNewest(3)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Failed to fetch news",
-			"data":    nil,
-		})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
 
@@ -136,98 +221,177 @@ func (h *NewsHandler) GetNewsBySlug(c *gin.Context) {
 
 	// Exclude reserved paths that should not be treated as news slugs
 	reservedPaths := map[string]bool{
-		"news":       true,
-		"categories": true,
-		"tags":       true,
-		"xinxun":     true,
-		"admin":      true,
-		"publisher":  true,
-		"health":     true,
+		"news":        true,
+		"categories":  true,
+		"tags":        true,
+		"collections": true,
+		"xinxun":      true,
+		"authors":     true,
+		"admin":       true,
+		"publisher":   true,
+		"health":      true,
 	}
 	if reservedPaths[slug] {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Artikel tidak ditemukan"})
+		apierror.Respond(c, apierror.NotFound("news_not_found"))
 		return
 	}
 
 	news, err := h.newsRepo.FindBySlug(slug)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Artikel tidak ditemukan"})
+		apierror.Respond(c, apierror.NotFound("news_not_found"))
+		return
+	}
+
+	translations, err := newsTranslations(news)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	categoryPath, err := h.categoryRepo.FindPath(news.CategoryID)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
 
 	// Increment views
 	go h.newsRepo.IncrementViews(news.ID)
 
-	c.JSON(http.StatusOK, gin.H{"data": news})
+	// Category and tag names follow the article language
+	news.Category.Name = news.Category.Names.Get(news.Language, news.Category.Name)
+	localizeTags(news.Tags, news.Language)
+	localizeCategories(categoryPath, news.Language)
+
+	detail := dto.NewPublicNews(*news)
+	detail.Translations = translations
+	detail.Breadcrumbs = dto.NewBreadcrumbs(categoryPath)
+	c.JSON(http.StatusOK, gin.H{"data": detail})
+}
+
+// GetNewsContent returns the content of a published article as blocks,
+// HTML and plain text. Articles written as raw HTML have no blocks.
+func (h *NewsHandler) GetNewsContent(c *gin.Context) {
+	news, err := h.newsRepo.FindBySlug(c.Param("slug"))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("news_not_found"))
+		return
+	}
+
+	content := dto.NewsContent{
+		ID:     news.ID,
+		Slug:   news.Slug,
+		Format: "html",
+		Blocks: models.ContentBlocks{},
+		HTML:   news.Content,
+		Text:   blocks.HTMLText(news.Content),
+	}
+	if len(news.ContentBlocks) > 0 {
+		content.Format = "blocks"
+		content.Blocks = news.ContentBlocks
+		content.Text = blocks.PlainText(news.ContentBlocks)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": content})
+}
+
+// GetNewsByID returns the full article for the editor screens. Publishers
+// can only open their own articles.
+func (h *NewsHandler) GetNewsByID(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	news, err := h.newsRepo.FindByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("news_not_found"))
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userType, _ := c.Get("user_type")
+	if userType == string(models.UserTypePublisher) && news.AuthorID != userID.(uint) {
+		apierror.Respond(c, apierror.Forbidden("news_view_forbidden"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewNewsForRole(*news, userTypeString(c))})
 }
 
 func (h *NewsHandler) SearchNews(c *gin.Context) {
 	query := c.Query("q")
 	if query == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter query 'q' wajib diisi"})
+		apierror.Respond(c, apierror.BadRequest("news_search_query_required"))
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-
-	if limit > 100 {
-		limit = 100
+	params, ok := parseListParams(c, 10)
+	if !ok {
+		return
 	}
-	if page < 1 {
-		page = 1
+	lang, ok := parseContentLanguage(c)
+	if !ok {
+		return
 	}
 
-	offset := (page - 1) * limit
-
-	news, total, err := h.newsRepo.FindAll(limit, offset, query, "", nil)
+	news, total, next, err := h.newsRepo.FindAll(repository.NewsFilter{
+		Search:         query,
+		Language:       lang,
+		Limit:          params.Limit,
+		Offset:         params.Offset(),
+		Cursor:         params.Cursor,
+		WithoutContent: true,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data": news,
-		"meta": gin.H{
-			"total": total,
-			"page":  page,
-			"limit": limit,
-			"pages": (int(total) + limit - 1) / limit,
-		},
-	})
+	respondNewsList(c, news, total, next, params)
 }
 
 type CreateNewsRequest struct {
-	Title      string `json:"title" binding:"required"`
-	Content    string `json:"content" binding:"required"`
-	Excerpt    string `json:"excerpt" binding:"required"`
-	Thumbnail  string `json:"thumbnail" binding:"required"`
-	CategoryID uint   `json:"category_id" binding:"required"`
-	TagIDs     []uint `json:"tag_ids"`
-	Status     string `json:"status"`
+	Title         string               `json:"title" binding:"required"`
+	Content       string               `json:"content"`   // Raw HTML, required unless Blocks is given
+	Blocks        models.ContentBlocks `json:"blocks"`    // Structured alternative to Content; Content is rendered from it
+	Excerpt       string               `json:"excerpt"`   // Generated from the content when empty
+	Thumbnail     string               `json:"thumbnail"` // First content image when empty
+	CategoryID    uint                 `json:"category_id" binding:"required"`
+	TagIDs        []uint               `json:"tag_ids"`
+	TagNames      []string             `json:"tag_names"` // Existing or new tags by name
+	Status        string               `json:"status"`
+	Language      string               `json:"language"`       // Defaults to Indonesian
+	TranslationOf *uint                `json:"translation_of"` // ID of the article this one translates
 }
 
 func (h *NewsHandler) CreateNews(c *gin.Context) {
 	var req CreateNewsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Bind(err))
 		return
 	}
 
 	userID, _ := c.Get("user_id")
 	userType, _ := c.Get("user_type")
 
-	// Validate title length (max 100 words)
-	titleWords := strings.Fields(strings.TrimSpace(req.Title))
-	if len(titleWords) > 100 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Judul tidak boleh lebih dari 100 kata"})
+	if !checkSubmissionQuota(c) {
+		return
+	}
+
+	content, contentBlocks, err := resolveContent(req.Content, req.Blocks)
+	if err != nil {
+		apierror.Respond(c, err)
 		return
 	}
 
-	// Validate excerpt length (max 200 words)
-	excerptWords := strings.Fields(strings.TrimSpace(req.Excerpt))
-	if len(excerptWords) > 200 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Excerpt tidak boleh lebih dari 200 kata"})
+	tags, ok := resolveTags(c, req.TagIDs, req.TagNames)
+	if !ok {
+		return
+	}
+
+	if !validateArticle(c, validation.Article{
+		Title:     req.Title,
+		Excerpt:   req.Excerpt,
+		Content:   content,
+		TagCount:  len(tags),
+		Thumbnail: req.Thumbnail,
+	}) {
 		return
 	}
 
@@ -235,11 +399,11 @@ func (h *NewsHandler) CreateNews(c *gin.Context) {
 	if userType == string(models.UserTypePublisher) {
 		category, err := h.categoryRepo.FindByID(req.CategoryID)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Kategori tidak ditemukan"})
+			apierror.Respond(c, apierror.BadRequest("category_not_found"))
 			return
 		}
 		if category.IsAdminOnly {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Kategori ini hanya untuk admin. Hanya admin yang dapat memublikasikan artikel dengan kategori ini."})
+			apierror.Respond(c, apierror.Forbidden("category_admin_only"))
 			return
 		}
 	}
@@ -254,7 +418,7 @@ func (h *NewsHandler) CreateNews(c *gin.Context) {
 	// Publisher news goes to pending, admin can publish directly
 	status := models.StatusDraft
 	var publishedAt *time.Time
-	if userType == string(models.UserTypeAdmin) {
+	if models.UserType(userTypeString(c)).IsStaff() {
 		if req.Status == "published" {
 			status = models.StatusPublished
 			now := time.Now()
@@ -266,48 +430,63 @@ func (h *NewsHandler) CreateNews(c *gin.Context) {
 	}
 
 	news := &models.News{
-		Title:       req.Title,
-		Slug:        newsSlug,
-		Content:     req.Content,
-		Excerpt:     req.Excerpt,
-		Thumbnail:   req.Thumbnail,
-		CategoryID:  req.CategoryID,
-		AuthorID:    userID.(uint),
-		Status:      status,
-		PublishedAt: publishedAt,
-	}
-
-	if len(req.TagIDs) > 0 {
-		var tags []models.Tag
-		for _, tagID := range req.TagIDs {
-			tags = append(tags, models.Tag{ID: tagID})
-		}
-		news.Tags = tags
+		Title:         req.Title,
+		Slug:          newsSlug,
+		Content:       content,
+		ContentBlocks: contentBlocks,
+		Excerpt:       req.Excerpt,
+		Thumbnail:     req.Thumbnail,
+		CategoryID:    req.CategoryID,
+		AuthorID:      userID.(uint),
+		Status:        status,
+		PublishedAt:   publishedAt,
+		Language:      i18n.Default,
+	}
+
+	if !applyTranslation(c, news, 0, req.Language, req.TranslationOf) {
+		return
+	}
+
+	if err := createMissingTags(c, tags); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	news.Tags = tags
+
+	if err := checkSimilarity(news); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
 	}
 
 	if err := h.newsRepo.Create(news); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
 
 	// Reload with relations
 	createdNews, err := h.newsRepo.FindByID(news.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"data": createdNews})
+	recordAudit(c, "news.create", models.AuditTargetNews, createdNews.ID, nil, auditSnapshot(createdNews))
+
+	c.JSON(http.StatusCreated, gin.H{"data": dto.NewNewsForRole(*createdNews, userTypeString(c))})
 }
 
 type UpdateNewsRequest struct {
-	Title      string `json:"title"`
-	Content    string `json:"content"`
-	Excerpt    string `json:"excerpt"`
-	Thumbnail  string `json:"thumbnail"`
-	CategoryID *uint  `json:"category_id"` // Use pointer to distinguish between "not provided" and "0"
-	TagIDs     []uint `json:"tag_ids"`
-	Status     string `json:"status"`
+	Title         string               `json:"title"`
+	Content       string               `json:"content"`
+	Blocks        models.ContentBlocks `json:"blocks"` // Replaces Content when given
+	Excerpt       string               `json:"excerpt"`
+	Thumbnail     string               `json:"thumbnail"`
+	CategoryID    *uint                `json:"category_id"` // Use pointer to distinguish between "not provided" and "0"
+	TagIDs        []uint               `json:"tag_ids"`
+	TagNames      []string             `json:"tag_names"` // Replaces the tags together with TagIDs
+	Status        string               `json:"status"`
+	Language      string               `json:"language"`
+	TranslationOf *uint                `json:"translation_of"` // 0 removes the article from its translation group
 }
 
 func (h *NewsHandler) UpdateNews(c *gin.Context) {
@@ -315,7 +494,7 @@ func (h *NewsHandler) UpdateNews(c *gin.Context) {
 
 	news, err := h.newsRepo.FindByID(uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Artikel tidak ditemukan"})
+		apierror.Respond(c, apierror.NotFound("news_not_found"))
 		return
 	}
 
@@ -324,18 +503,31 @@ func (h *NewsHandler) UpdateNews(c *gin.Context) {
 
 	// Check if user owns this news (for publisher)
 	if userType == string(models.UserTypePublisher) && news.AuthorID != userID.(uint) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Anda hanya dapat mengedit artikel milik Anda sendiri"})
+		apierror.Respond(c, apierror.Forbidden("news_edit_forbidden"))
 		return
 	}
 
 	var req UpdateNewsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Bind(err))
 		return
 	}
+	before := auditSnapshot(news)
+
+	// Publishers never pick a status: submissions go through admin review
+	if userType == string(models.UserTypePublisher) && req.Status != "" {
+		apierror.Respond(c, apierror.Forbidden("news_status_publisher_forbidden"))
+		return
+	}
+
+	// A publisher editing an article that already left review (published,
+	// unpublished, archived or rejected) gets a new revision instead of a
+	// direct update. Drafts and pending submissions are still under review.
+	if userType == string(models.UserTypePublisher) && news.Status != models.StatusDraft && news.Status != models.StatusPending {
+		if !checkSubmissionQuota(c) {
+			return
+		}
 
-	// If publisher is editing a published news, create a new revision instead of updating directly
-	if userType == string(models.UserTypePublisher) && news.Status == models.StatusPublished {
 		// Create new revision with pending status
 		newsSlug := slug.Make(req.Title)
 		if req.Title == "" {
@@ -352,17 +544,23 @@ func (h *NewsHandler) UpdateNews(c *gin.Context) {
 		if revisionTitle == "" {
 			revisionTitle = news.Title
 		}
-		revisionContent := req.Content
-		if revisionContent == "" {
-			revisionContent = news.Content
+		revisionContent, revisionBlocks := news.Content, news.ContentBlocks
+		if req.Content != "" || len(req.Blocks) > 0 {
+			var err error
+			revisionContent, revisionBlocks, err = resolveContent(req.Content, req.Blocks)
+			if err != nil {
+				apierror.Respond(c, err)
+				return
+			}
 		}
-		revisionExcerpt := req.Excerpt
+		// Generated values stay generated so they follow the new content
+		revisionExcerpt, autoExcerpt := req.Excerpt, false
 		if revisionExcerpt == "" {
-			revisionExcerpt = news.Excerpt
+			revisionExcerpt, autoExcerpt = news.Excerpt, news.AutoExcerpt
 		}
-		revisionThumbnail := req.Thumbnail
+		revisionThumbnail, autoThumbnail := req.Thumbnail, false
 		if revisionThumbnail == "" {
-			revisionThumbnail = news.Thumbnail
+			revisionThumbnail, autoThumbnail = news.Thumbnail, news.AutoThumbnail
 		}
 		revisionCategoryID := news.CategoryID // Default to original
 		if req.CategoryID != nil {
@@ -370,53 +568,45 @@ func (h *NewsHandler) UpdateNews(c *gin.Context) {
 		}
 
 		revision := &models.News{
-			Title:      revisionTitle,
-			Slug:       newsSlug,
-			Content:    revisionContent,
-			Excerpt:    revisionExcerpt,
-			Thumbnail:  revisionThumbnail,
-			CategoryID: revisionCategoryID,
-			AuthorID:   news.AuthorID,
-			Status:     models.StatusPending,
-			RevisionOf: &news.ID, // Link to original
+			Title:         revisionTitle,
+			Slug:          newsSlug,
+			Content:       revisionContent,
+			ContentBlocks: revisionBlocks,
+			Excerpt:       revisionExcerpt,
+			Thumbnail:     revisionThumbnail,
+			AutoExcerpt:   autoExcerpt,
+			AutoThumbnail: autoThumbnail,
+			CategoryID:    revisionCategoryID,
+			AuthorID:      news.AuthorID,
+			Status:        models.StatusPending,
+			RevisionOf:    &news.ID, // Link to original
+			Language:      news.Language,
+
+			TranslationGroupID: news.TranslationGroupID,
+		}
+
+		if !applyTranslation(c, revision, news.ID, req.Language, req.TranslationOf) {
+			return
 		}
 
 		// Validate category access if category is being changed
 		if req.CategoryID != nil {
 			category, err := h.categoryRepo.FindByID(*req.CategoryID)
 			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Kategori tidak ditemukan"})
+				apierror.Respond(c, apierror.BadRequest("category_not_found"))
 				return
 			}
 			if category.IsAdminOnly {
-				c.JSON(http.StatusForbidden, gin.H{"error": "Kategori ini hanya untuk admin. Hanya admin yang dapat memublikasikan artikel dengan kategori ini."})
-				return
-			}
-		}
-
-		// Validate title length
-		if req.Title != "" {
-			titleWords := strings.Fields(strings.TrimSpace(req.Title))
-			if len(titleWords) > 100 {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Judul tidak boleh lebih dari 100 kata"})
-				return
-			}
-		}
-
-		// Validate excerpt length
-		if req.Excerpt != "" {
-			excerptWords := strings.Fields(strings.TrimSpace(req.Excerpt))
-			if len(excerptWords) > 200 {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Excerpt tidak boleh lebih dari 200 kata"})
+				apierror.Respond(c, apierror.Forbidden("category_admin_only"))
 				return
 			}
 		}
 
 		// Set tags
-		if len(req.TagIDs) > 0 {
-			var tags []models.Tag
-			for _, tagID := range req.TagIDs {
-				tags = append(tags, models.Tag{ID: tagID})
+		if len(req.TagIDs) > 0 || len(req.TagNames) > 0 {
+			tags, ok := resolveTags(c, req.TagIDs, req.TagNames)
+			if !ok {
+				return
 			}
 			revision.Tags = tags
 		} else {
@@ -424,56 +614,75 @@ func (h *NewsHandler) UpdateNews(c *gin.Context) {
 			revision.Tags = news.Tags
 		}
 
+		if !validateArticle(c, validation.Article{
+			Title:     revision.Title,
+			Excerpt:   revision.Excerpt,
+			Content:   revision.Content,
+			TagCount:  len(revision.Tags),
+			Thumbnail: req.Thumbnail,
+		}) {
+			return
+		}
+
+		if err := createMissingTags(c, revision.Tags); err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+
+		if err := checkSimilarity(revision); err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+
 		if err := h.newsRepo.Create(revision); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			apierror.Respond(c, apierror.Internal(err))
 			return
 		}
 
 		// Reload with relations
 		createdRevision, err := h.newsRepo.FindByID(revision.ID)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			apierror.Respond(c, apierror.Internal(err))
 			return
 		}
 
+		recordAudit(c, "news.create_revision", models.AuditTargetNews, createdRevision.ID, nil, auditSnapshot(createdRevision))
+
 		c.JSON(http.StatusCreated, gin.H{
 			"message": "Revisi berhasil dibuat. Menunggu approval admin.",
-			"data":    createdRevision,
+			"data":    dto.NewNewsForRole(*createdRevision, userTypeString(c)),
 		})
 		return
 	}
 
 	if req.Title != "" {
-		// Validate title length (max 100 words)
-		titleWords := strings.Fields(strings.TrimSpace(req.Title))
-		if len(titleWords) > 100 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Judul tidak boleh lebih dari 100 kata"})
-			return
-		}
 		news.Title = req.Title
 		news.Slug = slug.Make(req.Title)
 	}
-	if req.Content != "" {
-		news.Content = req.Content
-	}
-	if req.Excerpt != "" {
-		// Validate excerpt length (max 200 words)
-		excerptWords := strings.Fields(strings.TrimSpace(req.Excerpt))
-		if len(excerptWords) > 200 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Excerpt tidak boleh lebih dari 200 kata"})
+	contentChanged := req.Content != "" || len(req.Blocks) > 0
+	if contentChanged {
+		content, contentBlocks, err := resolveContent(req.Content, req.Blocks)
+		if err != nil {
+			apierror.Respond(c, err)
 			return
 		}
+		news.Content = content
+		news.ContentBlocks = contentBlocks
+	}
+	if req.Excerpt != "" {
 		news.Excerpt = req.Excerpt
+		news.AutoExcerpt = false
 	}
 	if req.Thumbnail != "" {
 		news.Thumbnail = req.Thumbnail
+		news.AutoThumbnail = false
 	}
 	// Update category if provided (using pointer to distinguish between "not provided" and "0")
 	if req.CategoryID != nil {
 		// Validate category exists
 		category, err := h.categoryRepo.FindByID(*req.CategoryID)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Kategori tidak ditemukan"})
+			apierror.Respond(c, apierror.BadRequest("category_not_found"))
 			return
 		}
 
@@ -481,7 +690,7 @@ func (h *NewsHandler) UpdateNews(c *gin.Context) {
 		userType, _ := c.Get("user_type")
 		if userType == string(models.UserTypePublisher) {
 			if category.IsAdminOnly {
-				c.JSON(http.StatusForbidden, gin.H{"error": "Kategori ini hanya untuk admin. Hanya admin yang dapat memublikasikan artikel dengan kategori ini."})
+				apierror.Respond(c, apierror.Forbidden("category_admin_only"))
 				return
 			}
 		}
@@ -489,6 +698,9 @@ func (h *NewsHandler) UpdateNews(c *gin.Context) {
 		// Update category
 		news.CategoryID = *req.CategoryID
 	}
+	if !applyTranslation(c, news, news.ID, req.Language, req.TranslationOf) {
+		return
+	}
 	if req.Status != "" {
 		if req.Status == "published" {
 			news.Status = models.StatusPublished
@@ -500,29 +712,60 @@ func (h *NewsHandler) UpdateNews(c *gin.Context) {
 			news.Status = models.StatusDraft
 		}
 	}
-	if len(req.TagIDs) > 0 {
-		var tags []models.Tag
-		for _, tagID := range req.TagIDs {
-			tags = append(tags, models.Tag{ID: tagID})
+	if len(req.TagIDs) > 0 || len(req.TagNames) > 0 {
+		tags, ok := resolveTags(c, req.TagIDs, req.TagNames)
+		if !ok {
+			return
 		}
 		news.Tags = tags
 	}
 
+	if !validateArticle(c, validation.Article{
+		Title:     news.Title,
+		Excerpt:   news.Excerpt,
+		Content:   news.Content,
+		TagCount:  len(news.Tags),
+		Thumbnail: req.Thumbnail,
+	}) {
+		return
+	}
+
+	if err := createMissingTags(c, news.Tags); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	// Content changes of a submission still under review are checked again
+	if contentChanged && news.Status == models.StatusPending {
+		if err := checkSimilarity(news); err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+	}
+
 	if err := h.newsRepo.Update(news); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": news})
+	recordAudit(c, "news.update", models.AuditTargetNews, news.ID, before, auditSnapshot(news))
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewNewsForRole(*news, userTypeString(c))})
 }
 
 func (h *NewsHandler) DeleteNews(c *gin.Context) {
 	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 
+	var before map[string]interface{}
+	if news, err := h.newsRepo.FindByID(uint(id)); err == nil {
+		before = auditSnapshot(news)
+	}
+
 	if err := h.newsRepo.Delete(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
+	recordAudit(c, "news.delete", models.AuditTargetNews, uint(id), before, nil)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Artikel berhasil dihapus"})
 }
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/dto"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/trash"
+
+	"github.com/gin-gonic/gin"
+)
+
+// statusTransitions lists, per target status of the lifecycle endpoints,
+// the statuses an article may move from
+var statusTransitions = map[models.NewsStatus][]models.NewsStatus{
+	models.StatusUnpublished: {models.StatusPublished},
+	models.StatusArchived:    {models.StatusPublished, models.StatusUnpublished},
+	models.StatusPublished:   {models.StatusUnpublished, models.StatusArchived},
+}
+
+// statusActions are the audit log actions of the lifecycle endpoints
+var statusActions = map[models.NewsStatus]string{
+	models.StatusUnpublished: "news.unpublish",
+	models.StatusArchived:    "news.archive",
+	models.StatusPublished:   "news.republish",
+}
+
+// UnpublishNews hides a published article until it is republished
+func (h *AdminHandler) UnpublishNews(c *gin.Context) {
+	h.respondStatusChange(c, models.StatusUnpublished, "Artikel berhasil di-unpublish")
+}
+
+// ArchiveNews moves a published or unpublished article to the archive
+func (h *AdminHandler) ArchiveNews(c *gin.Context) {
+	h.respondStatusChange(c, models.StatusArchived, "Artikel berhasil diarsipkan")
+}
+
+// RepublishNews publishes an unpublished or archived article again
+func (h *AdminHandler) RepublishNews(c *gin.Context) {
+	h.respondStatusChange(c, models.StatusPublished, "Artikel berhasil diterbitkan kembali")
+}
+
+func (h *AdminHandler) respondStatusChange(c *gin.Context, status models.NewsStatus, message string) {
+	if !requireStaff(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	news, err := h.newsRepo.FindByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("news_not_found"))
+		return
+	}
+
+	if err := h.changeStatus(c, news, status); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    dto.NewAdminNews(*news),
+	})
+}
+
+// changeStatus moves an article along statusTransitions and records the
+// change in the audit log
+func (h *AdminHandler) changeStatus(c *gin.Context, news *models.News, status models.NewsStatus) error {
+	allowed := false
+	for _, from := range statusTransitions[status] {
+		if news.Status == from {
+			allowed = true
+			break
+		}
+	}
+	if !allowed || news.RevisionOf != nil {
+		return apierror.BadRequest("news_status_change_invalid", news.Status, status)
+	}
+
+	before := auditSnapshot(news)
+	if err := h.newsRepo.SetStatus(news.ID, status); err != nil {
+		return apierror.Internal(err)
+	}
+	news.Status = status
+
+	recordAudit(c, statusActions[status], models.AuditTargetNews, news.ID, before, auditSnapshot(news))
+	return nil
+}
+
+// GetTrash lists soft-deleted articles with the time each one will be
+// purged
+func (h *AdminHandler) GetTrash(c *gin.Context) {
+	if !requireStaff(c) {
+		return
+	}
+
+	params, ok := parseListParams(c, 20)
+	if !ok {
+		return
+	}
+
+	news, total, next, err := h.newsRepo.FindTrashed(params.Limit, params.Offset(), params.Cursor)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	items := make([]dto.TrashedNews, 0, len(news))
+	for _, item := range news {
+		deletedAt := item.DeletedAt.Time
+		items = append(items, dto.TrashedNews{
+			NewsListItem: dto.NewNewsListItem(item),
+			DeletedAt:    deletedAt,
+			PurgeAt:      trash.PurgeAt(deletedAt),
+		})
+	}
+
+	meta := listMeta(total, next, params)
+	meta["retention_days"] = trash.RetentionDays()
+	c.JSON(http.StatusOK, gin.H{"data": items, "meta": meta})
+}
+
+// RestoreNews takes an article out of the trash with the status it had
+// when it was deleted
+func (h *AdminHandler) RestoreNews(c *gin.Context) {
+	if !requireStaff(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if _, err := h.newsRepo.FindTrashedByID(uint(id)); err != nil {
+		apierror.Respond(c, apierror.NotFound("news_not_in_trash"))
+		return
+	}
+
+	if err := h.newsRepo.Restore(uint(id)); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	restored, err := h.newsRepo.FindByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "news.restore", models.AuditTargetNews, restored.ID, nil, auditSnapshot(restored))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Artikel berhasil dipulihkan",
+		"data":    dto.NewAdminNews(*restored),
+	})
+}
+
+// PurgeNews permanently deletes an article from the trash, together with
+// the uploaded media no other article uses
+func (h *AdminHandler) PurgeNews(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	news, err := h.newsRepo.FindTrashedByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("news_not_in_trash"))
+		return
+	}
+	before := auditSnapshot(news)
+
+	if err := trash.Purge(news); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "news.purge", models.AuditTargetNews, news.ID, before, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Artikel berhasil dihapus permanen"})
+}
+
+// requireStaff rejects requests that do not come from an admin or editor
+func requireStaff(c *gin.Context) bool {
+	if !models.UserType(userTypeString(c)).IsStaff() {
+		apierror.Respond(c, apierror.Forbidden("staff_required"))
+		return false
+	}
+	return true
+}
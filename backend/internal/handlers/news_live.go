@@ -0,0 +1,419 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/dto"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxLiveUpdatesPage is the most live updates returned by one request
+const maxLiveUpdatesPage = 100
+
+// liveUpdateOverlap is how far back a caught-up poll cursor reaches
+const liveUpdateOverlap = 2 * time.Second
+
+// GetBreakingNews lists the published articles currently flagged as
+// breaking news, newest first
+func (h *NewsHandler) GetBreakingNews(c *gin.Context) {
+	lang, ok := parseContentLanguage(c)
+	if !ok {
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	if err != nil || limit < 1 || limit > 20 {
+		apierror.Respond(c, apierror.BadRequest("query_param_invalid", "limit"))
+		return
+	}
+
+	news, err := h.newsRepo.FindBreaking(limit, lang)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	localizeNews(news, lang)
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewNewsList(news)})
+}
+
+// GetLiveUpdates returns the entries of a published live blog. See
+// respondLiveUpdates for polling with ?since=.
+func (h *NewsHandler) GetLiveUpdates(c *gin.Context) {
+	news, err := h.newsRepo.FindBySlug(c.Param("slug"))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("news_not_found"))
+		return
+	}
+	respondLiveUpdates(c, news)
+}
+
+// GetLiveUpdates returns the entries of any live blog, published or not
+// (staff only)
+func (h *AdminHandler) GetLiveUpdates(c *gin.Context) {
+	news, ok := h.findLiveBlog(c)
+	if !ok {
+		return
+	}
+	respondLiveUpdates(c, news)
+}
+
+// respondLiveUpdates writes the entries of a live blog. Without since, it
+// returns the newest entries (next_before pages back through older ones).
+// With since, it returns the entries created or edited after that point,
+// oldest change first, and the ids deleted since then. Clients poll with
+// the next_since of the previous response and replace entries by id, as
+// the most recent changes can be sent twice.
+func respondLiveUpdates(c *gin.Context, news *models.News) {
+	if news.Type != models.TypeLiveBlog {
+		apierror.Respond(c, apierror.BadRequest("news_not_live_blog"))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > maxLiveUpdatesPage {
+		apierror.Respond(c, apierror.BadRequest("query_param_invalid", "limit"))
+		return
+	}
+	since, ok := parseLiveCursor(c, "since")
+	if !ok {
+		return
+	}
+	before, ok := parseLiveCursor(c, "before")
+	if !ok {
+		return
+	}
+
+	// Taken before reading so changes made during the request are picked
+	// up by the next poll
+	now := time.Now()
+	liveRepo := repository.NewLiveUpdateRepository()
+	meta := gin.H{"server_time": now}
+
+	var updates []models.LiveUpdate
+	if since != nil {
+		updates, err = liveRepo.FindChangedAfter(news.ID, *since, limit+1)
+		if err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+		deleted, err := liveRepo.FindDeletedSince(news.ID, since.CreatedAt)
+		if err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+		meta["deleted_ids"] = deleted
+	} else {
+		updates, err = liveRepo.FindLatest(news.ID, before, limit+1)
+		if err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+	}
+
+	hasMore := len(updates) > limit
+	if hasMore {
+		updates = updates[:limit]
+	}
+	meta["has_more"] = hasMore
+	if since != nil {
+		meta["next_since"] = nextPollCursor(updates, hasMore, now).Encode()
+	} else {
+		meta["next_since"] = nextPollCursor(nil, false, now).Encode()
+		if hasMore {
+			last := updates[len(updates)-1]
+			meta["next_before"] = repository.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+		}
+	}
+
+	total, err := liveRepo.Count(news.ID)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	meta["total"] = total
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewLiveUpdates(updates), "meta": meta})
+}
+
+// parseLiveCursor reads the since or before parameter: a cursor from a
+// previous response, or an RFC 3339 time for the first request. It
+// responds and returns false when the value is invalid.
+func parseLiveCursor(c *gin.Context, name string) (*repository.Cursor, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, true
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &repository.Cursor{CreatedAt: t}, true
+	}
+	cursor, err := repository.DecodeCursor(raw)
+	if err != nil {
+		apierror.Respond(c, apierror.BadRequest("query_param_invalid", name))
+		return nil, false
+	}
+	return cursor, true
+}
+
+// nextPollCursor returns where the next poll continues. While more changes
+// are waiting it is the last entry returned, so entries sharing a second
+// are paged through by id. Otherwise it is now truncated to the second
+// precision of updated_at and moved back by liveUpdateOverlap, so entries
+// that were rounded down or committed after the read are sent again rather
+// than skipped.
+func nextPollCursor(updates []models.LiveUpdate, hasMore bool, now time.Time) repository.Cursor {
+	if hasMore && len(updates) > 0 {
+		last := updates[len(updates)-1]
+		return repository.Cursor{CreatedAt: last.UpdatedAt, ID: last.ID}
+	}
+	return repository.Cursor{CreatedAt: now.Truncate(time.Second).Add(-liveUpdateOverlap)}
+}
+
+// findLiveBlog loads the live blog article of the URL for the staff
+// endpoints. It responds and returns false when the caller is not staff or
+// the article is missing or not a live blog.
+func (h *AdminHandler) findLiveBlog(c *gin.Context) (*models.News, bool) {
+	if !requireStaff(c) {
+		return nil, false
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	news, err := h.newsRepo.FindByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("news_not_found"))
+		return nil, false
+	}
+	if news.Type != models.TypeLiveBlog {
+		apierror.Respond(c, apierror.BadRequest("news_not_live_blog"))
+		return nil, false
+	}
+	return news, true
+}
+
+type LiveUpdateRequest struct {
+	Content string               `json:"content"` // Raw HTML, required unless Blocks is given
+	Blocks  models.ContentBlocks `json:"blocks"`  // Structured alternative to Content
+}
+
+// CreateLiveUpdate appends a timestamped entry to a live blog (staff only)
+func (h *AdminHandler) CreateLiveUpdate(c *gin.Context) {
+	news, ok := h.findLiveBlog(c)
+	if !ok {
+		return
+	}
+
+	var req LiveUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+	content, contentBlocks, err := resolveContent(req.Content, req.Blocks)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	update := &models.LiveUpdate{
+		NewsID:        news.ID,
+		AuthorID:      userID.(uint),
+		Content:       content,
+		ContentBlocks: contentBlocks,
+	}
+	liveRepo := repository.NewLiveUpdateRepository()
+	if err := liveRepo.Create(update); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	if err := h.newsRepo.Touch(news.ID); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "live_update.create", models.AuditTargetLiveUpdate, update.ID, nil, auditSnapshot(update))
+
+	update, err = liveRepo.FindByID(news.ID, update.ID)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Live update berhasil ditambahkan",
+		"data":    dto.NewLiveUpdate(*update),
+	})
+}
+
+// UpdateLiveUpdate corrects an entry of a live blog (staff only). Polling
+// clients receive it again through updated_at.
+func (h *AdminHandler) UpdateLiveUpdate(c *gin.Context) {
+	news, ok := h.findLiveBlog(c)
+	if !ok {
+		return
+	}
+
+	liveRepo := repository.NewLiveUpdateRepository()
+	updateID, _ := strconv.ParseUint(c.Param("update_id"), 10, 32)
+	update, err := liveRepo.FindByID(news.ID, uint(updateID))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("live_update_not_found"))
+		return
+	}
+
+	var req LiveUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+	content, contentBlocks, err := resolveContent(req.Content, req.Blocks)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	before := auditSnapshot(update)
+
+	update.Content = content
+	update.ContentBlocks = contentBlocks
+	if err := liveRepo.Update(update); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	if err := h.newsRepo.Touch(news.ID); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "live_update.update", models.AuditTargetLiveUpdate, update.ID, before, auditSnapshot(update))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Live update berhasil diperbarui",
+		"data":    dto.NewLiveUpdate(*update),
+	})
+}
+
+// DeleteLiveUpdate removes an entry of a live blog (staff only). Polling
+// clients receive its id in deleted_ids.
+func (h *AdminHandler) DeleteLiveUpdate(c *gin.Context) {
+	news, ok := h.findLiveBlog(c)
+	if !ok {
+		return
+	}
+
+	liveRepo := repository.NewLiveUpdateRepository()
+	updateID, _ := strconv.ParseUint(c.Param("update_id"), 10, 32)
+	update, err := liveRepo.FindByID(news.ID, uint(updateID))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("live_update_not_found"))
+		return
+	}
+
+	if err := liveRepo.Delete(update.ID); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	if err := h.newsRepo.Touch(news.ID); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "live_update.delete", models.AuditTargetLiveUpdate, update.ID, auditSnapshot(update), nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Live update berhasil dihapus"})
+}
+
+type UpdateNewsTypeRequest struct {
+	Type models.NewsType `json:"type" binding:"required,oneof=standard live_blog"`
+}
+
+// UpdateNewsType turns an article into a live blog or back into a standard
+// article (staff only). Live updates are kept when switching back, but are
+// no longer served.
+func (h *AdminHandler) UpdateNewsType(c *gin.Context) {
+	if !requireStaff(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	news, err := h.newsRepo.FindByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("news_not_found"))
+		return
+	}
+	if news.RevisionOf != nil {
+		apierror.Respond(c, apierror.BadRequest("news_revision_not_allowed"))
+		return
+	}
+
+	var req UpdateNewsTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+	before := auditSnapshot(news)
+
+	news.Type = req.Type
+	if err := h.newsRepo.SetType(news.ID, news.Type); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "news.type", models.AuditTargetNews, news.ID, before, auditSnapshot(news))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tipe artikel berhasil diubah",
+		"data":    dto.NewAdminNews(*news),
+	})
+}
+
+type UpdateBreakingRequest struct {
+	IsBreaking    bool       `json:"is_breaking"`
+	BreakingUntil *time.Time `json:"breaking_until"` // null = breaking until cleared by hand
+}
+
+// UpdateBreaking flags an article as breaking news, optionally until a
+// given time (staff only)
+func (h *AdminHandler) UpdateBreaking(c *gin.Context) {
+	if !requireStaff(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	news, err := h.newsRepo.FindByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("news_not_found"))
+		return
+	}
+	if news.RevisionOf != nil {
+		apierror.Respond(c, apierror.BadRequest("news_revision_not_allowed"))
+		return
+	}
+
+	var req UpdateBreakingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+	if req.IsBreaking && req.BreakingUntil != nil && !req.BreakingUntil.After(time.Now()) {
+		apierror.Respond(c, apierror.BadRequest("curation_invalid").
+			WithDetails(apierror.Field("breaking_until", "curation_until_past")))
+		return
+	}
+	before := auditSnapshot(news)
+
+	news.IsBreaking = req.IsBreaking
+	news.BreakingUntil = nil
+	if req.IsBreaking {
+		news.BreakingUntil = req.BreakingUntil
+	}
+	if err := h.newsRepo.UpdateBreaking(news); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "news.breaking", models.AuditTargetNews, news.ID, before, auditSnapshot(news))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Status breaking news berhasil diperbarui",
+		"data":    dto.NewAdminNews(*news),
+	})
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNextPollCursorOverlapsRoundedEntries(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 900_000_000, time.UTC)
+	cursor := nextPollCursor(nil, false, now)
+
+	if cursor.ID != 0 {
+		t.Fatalf("caught-up cursor ID = %d, want 0", cursor.ID)
+	}
+	if !cursor.CreatedAt.Before(now.Truncate(time.Second)) {
+		t.Fatalf("caught-up cursor %v does not reach back before %v", cursor.CreatedAt, now)
+	}
+
+	// Saved at 12:00:00.3 but stored with second precision, and an entry
+	// committed just after the read
+	cases := []models.LiveUpdate{
+		{ID: 7, UpdatedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{ID: 8, UpdatedAt: time.Date(2026, 3, 1, 11, 59, 59, 0, time.UTC)},
+	}
+	for _, update := range cases {
+		// With ID 0 the cursor only includes entries strictly after it
+		if !cursor.CreatedAt.Before(update.UpdatedAt) {
+			t.Errorf("entry %d at %v is skipped by cursor %v", update.ID, update.UpdatedAt, cursor.CreatedAt)
+		}
+	}
+}
+
+func TestNextPollCursorResumesAfterLastEntry(t *testing.T) {
+	second := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	page := []models.LiveUpdate{
+		{ID: 3, UpdatedAt: second},
+		{ID: 4, UpdatedAt: second},
+	}
+	now := second.Add(10 * time.Second)
+
+	cursor := nextPollCursor(page, true, now)
+	if !cursor.CreatedAt.Equal(second) || cursor.ID != 4 {
+		t.Errorf("cursor = %v / %d, want %v / 4", cursor.CreatedAt, cursor.ID, second)
+	}
+
+	// A full page that is the last one still catches up to now
+	cursor = nextPollCursor(page, false, now)
+	if cursor.ID != 0 || !cursor.CreatedAt.Before(now) {
+		t.Errorf("caught-up cursor = %v / %d", cursor.CreatedAt, cursor.ID)
+	}
+}
+
+func TestParseLiveCursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	encoded := repository.Cursor{CreatedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), ID: 42}.Encode()
+
+	tests := []struct {
+		name   string
+		value  string
+		wantOK bool
+		wantID uint
+		wantAt time.Time
+	}{
+		{name: "missing", value: "", wantOK: true},
+		{name: "timestamp", value: "2026-03-01T12:00:00Z", wantOK: true, wantAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{name: "cursor", value: encoded, wantOK: true, wantID: 42, wantAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{name: "invalid", value: "yesterday", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+			c.Request = httptest.NewRequest(http.MethodGet, "/?since="+url.QueryEscape(tt.value), nil)
+
+			cursor, ok := parseLiveCursor(c, "since")
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				if recorder.Code != http.StatusBadRequest {
+					t.Errorf("status = %d, want 400", recorder.Code)
+				}
+				return
+			}
+			if tt.value == "" {
+				if cursor != nil {
+					t.Errorf("cursor = %+v, want nil", cursor)
+				}
+				return
+			}
+			if cursor.ID != tt.wantID || !cursor.CreatedAt.Equal(tt.wantAt) {
+				t.Errorf("cursor = %+v, want %v / %d", cursor, tt.wantAt, tt.wantID)
+			}
+		})
+	}
+}
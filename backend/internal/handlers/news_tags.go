@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gosimple/slug"
+)
+
+// maxTagNameLength is the longest tag name accepted in tag_names
+const maxTagNameLength = 100
+
+// normalizeTagName trims a tag name and collapses inner whitespace
+func normalizeTagName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// resolveTags turns the tag_ids and tag_names of a news request into tags.
+// Every id must exist. Names are matched by slug, following the redirects
+// of merged tags; names without a tag come back with ID 0 and are created
+// by createMissingTags once the article passes validation. Publishers may
+// only introduce new names when the publisher.create_tags setting allows
+// it. It responds and returns false on errors.
+func resolveTags(c *gin.Context, ids []uint, names []string) ([]models.Tag, bool) {
+	tagRepo := repository.NewTagRepository()
+	var tags []models.Tag
+	seen := map[uint]bool{}
+	var details []apierror.FieldError
+
+	if len(ids) > 0 {
+		found, err := tagRepo.FindByIDs(ids)
+		if err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return nil, false
+		}
+		byID := make(map[uint]models.Tag, len(found))
+		for _, tag := range found {
+			byID[tag.ID] = tag
+		}
+		for i, id := range ids {
+			tag, ok := byID[id]
+			if !ok {
+				details = append(details, apierror.Field(fmt.Sprintf("tag_ids[%d]", i), "tag_not_found"))
+				continue
+			}
+			if !seen[id] {
+				seen[id] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	var missing []models.Tag
+	newSlugs := map[string]bool{}
+	for i, raw := range names {
+		name := normalizeTagName(raw)
+		tagSlug := slug.Make(name)
+		if tagSlug == "" || len(name) > maxTagNameLength {
+			details = append(details, apierror.Field(fmt.Sprintf("tag_names[%d]", i), "tag_name_invalid", maxTagNameLength))
+			continue
+		}
+
+		tag, err := tagRepo.FindBySlug(tagSlug)
+		if err != nil {
+			tag, err = tagRepo.FindRedirect(tagSlug)
+		}
+		if err != nil {
+			if !newSlugs[tagSlug] {
+				newSlugs[tagSlug] = true
+				missing = append(missing, models.Tag{Name: name, Slug: tagSlug})
+			}
+			continue
+		}
+		if !seen[tag.ID] {
+			seen[tag.ID] = true
+			tags = append(tags, *tag)
+		}
+	}
+
+	if len(details) > 0 {
+		apierror.Respond(c, apierror.BadRequest("news_tags_invalid").WithDetails(details...))
+		return nil, false
+	}
+
+	if len(missing) > 0 && !canCreateTags(c) {
+		newNames := make([]string, 0, len(missing))
+		for _, tag := range missing {
+			newNames = append(newNames, tag.Name)
+		}
+		apierror.Respond(c, apierror.Forbidden("tag_create_forbidden").With("names", newNames))
+		return nil, false
+	}
+
+	return append(tags, missing...), true
+}
+
+// canCreateTags reports whether the user may create tags while writing an
+// article
+func canCreateTags(c *gin.Context) bool {
+	if models.UserType(userTypeString(c)).IsStaff() {
+		return true
+	}
+	return repository.NewSettingRepository().GetBool(models.SettingPublisherCreateTags, false)
+}
+
+// createMissingTags creates the tags resolveTags could not find and fills
+// in their ids
+func createMissingTags(c *gin.Context, tags []models.Tag) error {
+	tagRepo := repository.NewTagRepository()
+	for i := range tags {
+		if tags[i].ID != 0 {
+			continue
+		}
+		if err := tagRepo.Create(&tags[i]); err != nil {
+			return err
+		}
+		if tags[i].ID == 0 {
+			// Create restored a soft-deleted tag with the same slug
+			restored, err := tagRepo.FindBySlug(tags[i].Slug)
+			if err != nil {
+				return err
+			}
+			tags[i] = *restored
+		}
+		recordAudit(c, "tag.create", models.AuditTargetTag, tags[i].ID, nil, auditSnapshot(&tags[i]))
+	}
+	return nil
+}
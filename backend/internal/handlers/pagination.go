@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/dto"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// listParams holds the pagination parameters shared by list endpoints.
+// Clients either page with ?page=N or follow ?cursor= from next_cursor.
+type listParams struct {
+	Limit  int
+	Page   int
+	Cursor *repository.Cursor
+	Fields []string
+}
+
+func (p listParams) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+// parseListParams reads limit, page, cursor and fields from the query string.
+// It writes a 400 response and returns false when the cursor is malformed.
+func parseListParams(c *gin.Context, defaultLimit int) (listParams, bool) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultLimit)))
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+
+	if limit > 100 {
+		limit = 100
+	}
+	if limit < 1 {
+		limit = defaultLimit
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	params := listParams{
+		Limit:  limit,
+		Page:   page,
+		Fields: dto.ParseFields(c.Query("fields")),
+	}
+
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := repository.DecodeCursor(raw)
+		if err != nil {
+			apierror.Respond(c, apierror.BadRequest("pagination_invalid_cursor"))
+			return params, false
+		}
+		params.Cursor = cursor
+	}
+
+	return params, true
+}
+
+// listMeta builds the pagination meta shared by list endpoints
+func listMeta(total int64, next *repository.Cursor, params listParams) gin.H {
+	meta := gin.H{
+		"total":       total,
+		"page":        params.Page,
+		"limit":       params.Limit,
+		"pages":       (int(total) + params.Limit - 1) / params.Limit,
+		"next_cursor": nil,
+	}
+	if next != nil {
+		meta["next_cursor"] = next.Encode()
+	}
+	return meta
+}
+
+// respondNewsList writes a lean news list with pagination meta, applying the
+// sparse fieldset when one was requested
+func respondNewsList(c *gin.Context, news []models.News, total int64, next *repository.Cursor, params listParams) {
+	localizeNews(news, contentLanguage(c))
+	respondNewsItems(c, dto.NewNewsList(news), total, next, params)
+}
+
+// respondNewsItems is respondNewsList for items the caller has already
+// built, e.g. to attach extra fields
+func respondNewsItems(c *gin.Context, news []dto.NewsListItem, total int64, next *repository.Cursor, params listParams) {
+	items, meta, err := newsItemsPayload(news, total, next, params)
+	if err != nil {
+		apierror.Respond(c, apierror.BadRequest("pagination_invalid_fields", err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": items, "meta": meta})
+}
+
+// newsListPayload builds the list items and pagination meta. It only fails
+// when the requested sparse fieldset names an unknown field.
+func newsListPayload(news []models.News, total int64, next *repository.Cursor, params listParams) (interface{}, gin.H, error) {
+	return newsItemsPayload(dto.NewNewsList(news), total, next, params)
+}
+
+func newsItemsPayload(items []dto.NewsListItem, total int64, next *repository.Cursor, params listParams) (interface{}, gin.H, error) {
+	meta := listMeta(total, next, params)
+
+	if len(params.Fields) == 0 {
+		return items, meta, nil
+	}
+
+	sparse, err := dto.SelectFields(items, params.Fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sparse, meta, nil
+}
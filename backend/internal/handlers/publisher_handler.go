@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
 
+	"xinxun-news/internal/apierror"
 	"xinxun-news/internal/database"
+	"xinxun-news/internal/dto"
 	"xinxun-news/internal/models"
 	"xinxun-news/internal/repository"
 	"xinxun-news/internal/services"
@@ -31,40 +34,45 @@ type PublisherLoginRequest struct {
 func (h *PublisherHandler) Login(c *gin.Context) {
 	var req PublisherLoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Bind(err))
 		return
 	}
 
 	// Validate phone number format (must start with 8 and have 10-13 digits)
 	if len(req.Number) < 10 || len(req.Number) > 13 || req.Number[0] != '8' {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Nomor telepon harus dimulai dengan 8 dan memiliki 10-13 digit"})
+		apierror.Respond(c, apierror.BadRequest("phone_number_invalid"))
 		return
 	}
 
 	// Validate that all characters are digits
 	for _, ch := range req.Number {
 		if ch < '0' || ch > '9' {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Nomor telepon hanya boleh berisi angka"})
+			apierror.Respond(c, apierror.BadRequest("phone_number_not_numeric"))
 			return
 		}
 	}
 
+	// Stop locked out accounts before the attempt reaches xinxun.us
+	lockoutKey := "publisher:" + req.Number
+	if !checkLoginLockout(c, lockoutKey) {
+		return
+	}
+
 	// Login to xinxun.us API
 	xinxunResp, err := services.LoginXinxun(req.Number, req.Password)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal menghubungi xinxun.us"})
+		apierror.Respond(c, apierror.New(http.StatusInternalServerError, "xinxun_unavailable").Wrap(err))
 		return
 	}
 
 	if !xinxunResp.Success {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"message": xinxunResp.Message,
-			"data":    nil,
-		})
+		recordLoginFailure(c, lockoutKey)
+		apierror.Respond(c, apierror.Unauthorized("xinxun_login_failed", xinxunResp.Message))
 		return
 	}
 
+	recordLoginSuccess(lockoutKey)
+
 	// Use FirstOrCreate to handle duplicate gracefully
 	username := "publisher_" + req.Number
 	hashedPassword, _ := services.HashPassword(req.Password)
@@ -79,7 +87,6 @@ func (h *PublisherHandler) Login(c *gin.Context) {
 		UserType:     models.UserTypePublisher,
 		XinxunID:     &xinxunID,
 		XinxunNumber: req.Number,
-		Balance:      xinxunResp.Data.Balance,
 		Status:       xinxunResp.Data.Status,
 		ReffCode:     xinxunResp.Data.ReffCode,
 	}
@@ -87,13 +94,12 @@ func (h *PublisherHandler) Login(c *gin.Context) {
 	// Use FirstOrCreate to handle race conditions and duplicates
 	user, err := h.userRepo.FirstOrCreate(newUser, username, req.Number)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal membuat/mengupdate pengguna: " + err.Error()})
+		apierror.Respond(c, apierror.New(http.StatusInternalServerError, "user_save_failed").Wrap(err))
 		return
 	}
 
 	// Update user data from xinxun (always update to sync latest data)
 	user.Name = xinxunResp.Data.Name
-	user.Balance = xinxunResp.Data.Balance
 	user.Status = xinxunResp.Data.Status
 	user.ReffCode = xinxunResp.Data.ReffCode
 	user.XinxunID = &xinxunID
@@ -105,14 +111,20 @@ func (h *PublisherHandler) Login(c *gin.Context) {
 	user.PasswordHash = hashedPassword
 
 	if err := h.userRepo.Update(user); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal mengupdate pengguna: " + err.Error()})
+		apierror.Respond(c, apierror.New(http.StatusInternalServerError, "user_save_failed").Wrap(err))
 		return
 	}
 
+	// Balance changes go through the ledger. A failed sync is retried on the
+	// next login and must not block this one.
+	if err := syncXinxunBalance(user, xinxunResp.Data.Balance); err != nil {
+		log.Printf("[Balance] Failed to sync balance for user %d: %v", user.ID, err)
+	}
+
 	// Generate JWT token
-	token, err := services.GenerateToken(user.ID, string(user.UserType))
+	token, err := services.GenerateToken(user.ID, string(user.UserType), user.TokenVersion, services.TokenFlags{})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal menghasilkan token"})
+		apierror.Respond(c, apierror.New(http.StatusInternalServerError, "token_generation_failed").Wrap(err))
 		return
 	}
 
@@ -121,18 +133,7 @@ func (h *PublisherHandler) Login(c *gin.Context) {
 		"message": "Login berhasil.",
 		"data": gin.H{
 			"token": token,
-			"user": gin.H{
-				"id":            user.ID,
-				"username":      user.Username,
-				"name":          user.Name,
-				"email":         user.Email,
-				"user_type":     user.UserType,
-				"xinxun_id":     user.XinxunID,
-				"xinxun_number": user.XinxunNumber,
-				"balance":       user.Balance,
-				"status":        user.Status,
-				"reff_code":     user.ReffCode,
-			},
+			"user":  dto.NewPublisherUser(*user),
 		},
 	})
 }
@@ -142,12 +143,12 @@ func (h *PublisherHandler) GetPublisherStatistics(c *gin.Context) {
 	userID, _ := c.Get("user_id")
 
 	var stats struct {
-		TotalPublished int64         `json:"total_published"`
-		TotalPending   int64         `json:"total_pending"`
-		TotalDraft     int64         `json:"total_draft"`
-		TotalRejected  int64         `json:"total_rejected"`
-		TotalViews     int64         `json:"total_views"`
-		TopNews        []models.News `json:"top_news"`
+		TotalPublished int64              `json:"total_published"`
+		TotalPending   int64              `json:"total_pending"`
+		TotalDraft     int64              `json:"total_draft"`
+		TotalRejected  int64              `json:"total_rejected"`
+		TotalViews     int64              `json:"total_views"`
+		TopNews        []dto.NewsListItem `json:"top_news"`
 	}
 
 	// Count by status for this publisher
@@ -174,7 +175,7 @@ func (h *PublisherHandler) GetPublisherStatistics(c *gin.Context) {
 	database.DB.Where("author_id = ? AND status = ?", userID, models.StatusPublished).
 		Preload("Category").Preload("Author").
 		Order("views DESC").Limit(5).Find(&topNews)
-	stats.TopNews = topNews
+	stats.TopNews = dto.NewNewsList(topNews)
 
 	c.JSON(http.StatusOK, gin.H{"data": stats})
 }
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Default publisher limits used until an admin changes them in settings
+const (
+	defaultPublisherMaxPending          = 5
+	defaultPublisherDailySubmissions    = 10
+	defaultPublisherTrustedAfter        = 10
+	defaultPublisherTrustedMaxPending   = 20
+	defaultPublisherTrustedDailySubmits = 50
+)
+
+// Publisher trust levels
+const (
+	TrustLevelNew     = "new"
+	TrustLevelTrusted = "trusted"
+)
+
+// PublisherQuota is a publisher's submission limits and current usage.
+// A limit of 0 means unlimited.
+type PublisherQuota struct {
+	TrustLevel         string    `json:"trust_level"`
+	ApprovedArticles   int64     `json:"approved_articles"`
+	TrustedAfter       int       `json:"trusted_after"`
+	MaxPending         int       `json:"max_pending"`
+	Pending            int64     `json:"pending"`
+	DailySubmissions   int       `json:"daily_submissions"`
+	SubmittedToday     int64     `json:"submitted_today"`
+	DailyLimitResetsAt time.Time `json:"daily_limit_resets_at"`
+}
+
+// loadPublisherQuota reads the configured limits and the publisher's usage
+func loadPublisherQuota(authorID uint, now time.Time) (*PublisherQuota, error) {
+	settingRepo := repository.NewSettingRepository()
+	newsRepo := repository.NewNewsRepository()
+
+	approved, err := newsRepo.CountPublishedByAuthor(authorID)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := newsRepo.CountByAuthorAndStatus(authorID, models.StatusPending)
+	if err != nil {
+		return nil, err
+	}
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	submitted, err := newsRepo.CountSubmittedSince(authorID, startOfDay)
+	if err != nil {
+		return nil, err
+	}
+
+	quota := &PublisherQuota{
+		TrustLevel:         TrustLevelNew,
+		ApprovedArticles:   approved,
+		TrustedAfter:       settingRepo.GetInt(models.SettingPublisherTrustedAfter, defaultPublisherTrustedAfter),
+		MaxPending:         settingRepo.GetInt(models.SettingPublisherMaxPending, defaultPublisherMaxPending),
+		Pending:            pending,
+		DailySubmissions:   settingRepo.GetInt(models.SettingPublisherDailySubmissions, defaultPublisherDailySubmissions),
+		SubmittedToday:     submitted,
+		DailyLimitResetsAt: startOfDay.AddDate(0, 0, 1),
+	}
+
+	if quota.TrustedAfter > 0 && approved >= int64(quota.TrustedAfter) {
+		quota.TrustLevel = TrustLevelTrusted
+		quota.MaxPending = settingRepo.GetInt(models.SettingPublisherTrustedMaxPending, defaultPublisherTrustedMaxPending)
+		quota.DailySubmissions = settingRepo.GetInt(models.SettingPublisherTrustedDailySubmits, defaultPublisherTrustedDailySubmits)
+	}
+
+	return quota, nil
+}
+
+// checkSubmissionQuota writes an error response and returns false when the
+// current publisher may not submit another article or revision. Staff are
+// never limited. Too many pending articles is a 403 because it only clears
+// after review; the daily limit is a 429 with Retry-After.
+func checkSubmissionQuota(c *gin.Context) bool {
+	if userTypeString(c) != string(models.UserTypePublisher) {
+		return true
+	}
+	userID, _ := c.Get("user_id")
+
+	now := time.Now()
+	quota, err := loadPublisherQuota(userID.(uint), now)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return false
+	}
+
+	if quota.MaxPending > 0 && quota.Pending >= int64(quota.MaxPending) {
+		apierror.Respond(c, apierror.Forbidden("pending_limit_reached", quota.Pending, quota.MaxPending).
+			With("quota", quota))
+		return false
+	}
+
+	if quota.DailySubmissions > 0 && quota.SubmittedToday >= int64(quota.DailySubmissions) {
+		retryAfter := int(quota.DailyLimitResetsAt.Sub(now).Seconds()) + 1
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		apierror.Respond(c, apierror.New(http.StatusTooManyRequests, "daily_submission_limit_reached", quota.DailySubmissions).
+			With("retry_after", retryAfter).
+			With("quota", quota))
+		return false
+	}
+
+	return true
+}
+
+// GetQuota returns the current publisher's limits and usage
+func (h *PublisherHandler) GetQuota(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	quota, err := loadPublisherQuota(userID.(uint), time.Now())
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": quota})
+}
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RewardHandler struct {
+	rewardRepo   *repository.RewardRepository
+	categoryRepo *repository.CategoryRepository
+}
+
+func NewRewardHandler() *RewardHandler {
+	return &RewardHandler{
+		rewardRepo:   repository.NewRewardRepository(),
+		categoryRepo: repository.NewCategoryRepository(),
+	}
+}
+
+// GetPolicies lists the reward policies, default policy first (admin only)
+func (h *RewardHandler) GetPolicies(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	policies, err := h.rewardRepo.FindPolicies()
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": policies})
+}
+
+type RewardPolicyRequest struct {
+	CategoryID     *uint                 `json:"category_id"` // Kosong untuk kebijakan default
+	BaseAmount     models.Money          `json:"base_amount"`
+	WordCountTiers models.WordCountTiers `json:"word_count_tiers"`
+	ThumbnailBonus models.Money          `json:"thumbnail_bonus"`
+}
+
+// validate writes a 400 response and returns false when the request has
+// negative amounts or an unknown category
+func (h *RewardHandler) validatePolicy(c *gin.Context, req RewardPolicyRequest) bool {
+	if req.BaseAmount < 0 || req.ThumbnailBonus < 0 {
+		apierror.Respond(c, apierror.BadRequest("reward_negative"))
+		return false
+	}
+	for _, tier := range req.WordCountTiers {
+		if tier.MinWords < 0 || tier.Bonus < 0 {
+			apierror.Respond(c, apierror.BadRequest("word_count_tier_invalid"))
+			return false
+		}
+	}
+	if req.CategoryID != nil {
+		if _, err := h.categoryRepo.FindByID(*req.CategoryID); err != nil {
+			apierror.Respond(c, apierror.BadRequest("category_not_found"))
+			return false
+		}
+	}
+	return true
+}
+
+// policyExists reports whether another policy already covers categoryID
+// (nil for the default policy)
+func (h *RewardHandler) policyExists(categoryID *uint, exceptID uint) bool {
+	policies, err := h.rewardRepo.FindPolicies()
+	if err != nil {
+		return false
+	}
+	for _, policy := range policies {
+		if policy.ID == exceptID {
+			continue
+		}
+		if (policy.CategoryID == nil && categoryID == nil) ||
+			(policy.CategoryID != nil && categoryID != nil && *policy.CategoryID == *categoryID) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreatePolicy creates the default policy or a category policy (admin only)
+func (h *RewardHandler) CreatePolicy(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req RewardPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+	if !h.validatePolicy(c, req) {
+		return
+	}
+	if h.policyExists(req.CategoryID, 0) {
+		apierror.Respond(c, apierror.BadRequest("reward_policy_exists"))
+		return
+	}
+
+	policy := &models.RewardPolicy{
+		CategoryID:     req.CategoryID,
+		BaseAmount:     req.BaseAmount,
+		WordCountTiers: req.WordCountTiers,
+		ThumbnailBonus: req.ThumbnailBonus,
+	}
+	if err := h.rewardRepo.CreatePolicy(policy); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "reward_policy.create", models.AuditTargetRewardPolicy, policy.ID, nil, auditSnapshot(policy))
+
+	c.JSON(http.StatusCreated, gin.H{"data": policy})
+}
+
+// UpdatePolicy replaces the amounts of a policy (admin only)
+func (h *RewardHandler) UpdatePolicy(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	policy, err := h.rewardRepo.FindPolicyByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("reward_policy_not_found"))
+		return
+	}
+
+	var req RewardPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+	if !h.validatePolicy(c, req) {
+		return
+	}
+	if h.policyExists(req.CategoryID, policy.ID) {
+		apierror.Respond(c, apierror.BadRequest("reward_policy_exists"))
+		return
+	}
+	before := auditSnapshot(policy)
+
+	policy.CategoryID = req.CategoryID
+	policy.BaseAmount = req.BaseAmount
+	policy.WordCountTiers = req.WordCountTiers
+	policy.ThumbnailBonus = req.ThumbnailBonus
+	if err := h.rewardRepo.UpdatePolicy(policy); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "reward_policy.update", models.AuditTargetRewardPolicy, policy.ID, before, auditSnapshot(policy))
+
+	c.JSON(http.StatusOK, gin.H{"data": policy})
+}
+
+// DeletePolicy deletes a policy (admin only). Articles of its category fall
+// back to the default policy.
+func (h *RewardHandler) DeletePolicy(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	policy, err := h.rewardRepo.FindPolicyByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("reward_policy_not_found"))
+		return
+	}
+
+	if err := h.rewardRepo.DeletePolicy(policy.ID); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "reward_policy.delete", models.AuditTargetRewardPolicy, policy.ID, auditSnapshot(policy), nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Kebijakan reward berhasil dihapus"})
+}
+
+// GetMilestones lists the view-milestone bonuses (admin only)
+func (h *RewardHandler) GetMilestones(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	milestones, err := h.rewardRepo.FindMilestones()
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": milestones})
+}
+
+type RewardMilestoneRequest struct {
+	Views int          `json:"views" binding:"required,min=1"`
+	Bonus models.Money `json:"bonus" binding:"required"`
+}
+
+// CreateMilestone adds a view-milestone bonus (admin only)
+func (h *RewardHandler) CreateMilestone(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req RewardMilestoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+	if req.Bonus < 0 {
+		apierror.Respond(c, apierror.BadRequest("bonus_negative"))
+		return
+	}
+
+	milestone := &models.RewardMilestone{Views: req.Views, Bonus: req.Bonus}
+	if err := h.rewardRepo.CreateMilestone(milestone); err != nil {
+		apierror.Respond(c, apierror.BadRequest("milestone_exists"))
+		return
+	}
+	recordAudit(c, "reward_milestone.create", models.AuditTargetRewardMilestone, milestone.ID, nil, auditSnapshot(milestone))
+
+	c.JSON(http.StatusCreated, gin.H{"data": milestone})
+}
+
+// UpdateMilestone changes the bonus of a milestone (admin only). Bonuses
+// already paid are not affected.
+func (h *RewardHandler) UpdateMilestone(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	milestone, err := h.rewardRepo.FindMilestoneByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("milestone_not_found"))
+		return
+	}
+
+	var req RewardMilestoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+	if req.Bonus < 0 {
+		apierror.Respond(c, apierror.BadRequest("bonus_negative"))
+		return
+	}
+	before := auditSnapshot(milestone)
+
+	milestone.Views = req.Views
+	milestone.Bonus = req.Bonus
+	if err := h.rewardRepo.UpdateMilestone(milestone); err != nil {
+		apierror.Respond(c, apierror.BadRequest("milestone_exists"))
+		return
+	}
+	recordAudit(c, "reward_milestone.update", models.AuditTargetRewardMilestone, milestone.ID, before, auditSnapshot(milestone))
+
+	c.JSON(http.StatusOK, gin.H{"data": milestone})
+}
+
+// DeleteMilestone removes a milestone (admin only)
+func (h *RewardHandler) DeleteMilestone(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	milestone, err := h.rewardRepo.FindMilestoneByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("milestone_not_found"))
+		return
+	}
+
+	if err := h.rewardRepo.DeleteMilestone(milestone.ID); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	recordAudit(c, "reward_milestone.delete", models.AuditTargetRewardMilestone, milestone.ID, auditSnapshot(milestone), nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Milestone berhasil dihapus"})
+}
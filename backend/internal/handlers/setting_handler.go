@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"net/http"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+	"xinxun-news/internal/trash"
+	"xinxun-news/internal/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SettingHandler struct {
+	settingRepo *repository.SettingRepository
+}
+
+func NewSettingHandler() *SettingHandler {
+	return &SettingHandler{
+		settingRepo: repository.NewSettingRepository(),
+	}
+}
+
+type SettingsResponse struct {
+	RequireTwoFactor bool `json:"require_two_factor"`
+	// Reward caps per publisher; 0 means no cap
+	RewardDailyCap   models.Money `json:"reward_daily_cap"`
+	RewardMonthlyCap models.Money `json:"reward_monthly_cap"`
+	// Publisher submission limits; 0 means unlimited
+	PublisherMaxPending              int `json:"publisher_max_pending"`
+	PublisherDailySubmissions        int `json:"publisher_daily_submissions"`
+	PublisherTrustedAfter            int `json:"publisher_trusted_after"`
+	PublisherTrustedMaxPending       int `json:"publisher_trusted_max_pending"`
+	PublisherTrustedDailySubmissions int `json:"publisher_trusted_daily_submissions"`
+	// Whether publishers may create new tags by name
+	PublisherCreateTags bool `json:"publisher_create_tags"`
+	// Days deleted articles stay in the trash; 0 keeps them until purged by hand
+	TrashRetentionDays int `json:"trash_retention_days"`
+	// Content quality rules; 0 disables a rule
+	ContentTitleMaxWords      int      `json:"content_title_max_words"`
+	ContentTitleMinChars      int      `json:"content_title_min_chars"`
+	ContentTitleMaxChars      int      `json:"content_title_max_chars"`
+	ContentExcerptMaxWords    int      `json:"content_excerpt_max_words"`
+	ContentMinWords           int      `json:"content_min_words"`
+	ContentThumbnailMinWidth  int      `json:"content_thumbnail_min_width"`
+	ContentThumbnailMinHeight int      `json:"content_thumbnail_min_height"`
+	ContentMaxLinks           int      `json:"content_max_links"`
+	ContentMinTags            int      `json:"content_min_tags"`
+	ContentBannedWords        []string `json:"content_banned_words"`
+}
+
+func (h *SettingHandler) currentSettings() SettingsResponse {
+	rules := validation.LoadRules()
+	return SettingsResponse{
+		RequireTwoFactor: h.settingRepo.GetBool(models.SettingRequireTwoFactor, false),
+		RewardDailyCap:   h.settingRepo.GetMoney(models.SettingRewardDailyCap),
+		RewardMonthlyCap: h.settingRepo.GetMoney(models.SettingRewardMonthlyCap),
+
+		PublisherMaxPending:              h.settingRepo.GetInt(models.SettingPublisherMaxPending, defaultPublisherMaxPending),
+		PublisherDailySubmissions:        h.settingRepo.GetInt(models.SettingPublisherDailySubmissions, defaultPublisherDailySubmissions),
+		PublisherTrustedAfter:            h.settingRepo.GetInt(models.SettingPublisherTrustedAfter, defaultPublisherTrustedAfter),
+		PublisherTrustedMaxPending:       h.settingRepo.GetInt(models.SettingPublisherTrustedMaxPending, defaultPublisherTrustedMaxPending),
+		PublisherTrustedDailySubmissions: h.settingRepo.GetInt(models.SettingPublisherTrustedDailySubmits, defaultPublisherTrustedDailySubmits),
+		PublisherCreateTags:              h.settingRepo.GetBool(models.SettingPublisherCreateTags, false),
+		TrashRetentionDays:               trash.RetentionDays(),
+
+		ContentTitleMaxWords:      rules.TitleMaxWords,
+		ContentTitleMinChars:      rules.TitleMinChars,
+		ContentTitleMaxChars:      rules.TitleMaxChars,
+		ContentExcerptMaxWords:    rules.ExcerptMaxWords,
+		ContentMinWords:           rules.ContentMinWords,
+		ContentThumbnailMinWidth:  rules.ThumbnailMinWidth,
+		ContentThumbnailMinHeight: rules.ThumbnailMinHeight,
+		ContentMaxLinks:           rules.MaxLinks,
+		ContentMinTags:            rules.MinTags,
+		ContentBannedWords:        rules.BannedWords,
+	}
+}
+
+// GetSettings returns the runtime settings (admin only)
+func (h *SettingHandler) GetSettings(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": h.currentSettings()})
+}
+
+type UpdateSettingsRequest struct {
+	RequireTwoFactor *bool         `json:"require_two_factor"`
+	RewardDailyCap   *models.Money `json:"reward_daily_cap"`
+	RewardMonthlyCap *models.Money `json:"reward_monthly_cap"`
+
+	PublisherMaxPending              *int  `json:"publisher_max_pending"`
+	PublisherDailySubmissions        *int  `json:"publisher_daily_submissions"`
+	PublisherTrustedAfter            *int  `json:"publisher_trusted_after"`
+	PublisherTrustedMaxPending       *int  `json:"publisher_trusted_max_pending"`
+	PublisherTrustedDailySubmissions *int  `json:"publisher_trusted_daily_submissions"`
+	PublisherCreateTags              *bool `json:"publisher_create_tags"`
+	TrashRetentionDays               *int  `json:"trash_retention_days"`
+
+	ContentTitleMaxWords      *int     `json:"content_title_max_words"`
+	ContentTitleMinChars      *int     `json:"content_title_min_chars"`
+	ContentTitleMaxChars      *int     `json:"content_title_max_chars"`
+	ContentExcerptMaxWords    *int     `json:"content_excerpt_max_words"`
+	ContentMinWords           *int     `json:"content_min_words"`
+	ContentThumbnailMinWidth  *int     `json:"content_thumbnail_min_width"`
+	ContentThumbnailMinHeight *int     `json:"content_thumbnail_min_height"`
+	ContentMaxLinks           *int     `json:"content_max_links"`
+	ContentMinTags            *int     `json:"content_min_tags"`
+	ContentBannedWords        []string `json:"content_banned_words"` // Replaces the whole list; send [] to clear it
+}
+
+// UpdateSettings changes the runtime settings (admin only). Fields left out
+// of the request keep their value.
+func (h *SettingHandler) UpdateSettings(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req UpdateSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	if (req.RewardDailyCap != nil && *req.RewardDailyCap < 0) ||
+		(req.RewardMonthlyCap != nil && *req.RewardMonthlyCap < 0) {
+		apierror.Respond(c, apierror.BadRequest("reward_cap_negative"))
+		return
+	}
+
+	intSettings := []struct {
+		key   string
+		value *int
+	}{
+		{models.SettingPublisherMaxPending, req.PublisherMaxPending},
+		{models.SettingPublisherDailySubmissions, req.PublisherDailySubmissions},
+		{models.SettingPublisherTrustedAfter, req.PublisherTrustedAfter},
+		{models.SettingPublisherTrustedMaxPending, req.PublisherTrustedMaxPending},
+		{models.SettingPublisherTrustedDailySubmits, req.PublisherTrustedDailySubmissions},
+		{models.SettingTrashRetentionDays, req.TrashRetentionDays},
+		{models.SettingContentTitleMaxWords, req.ContentTitleMaxWords},
+		{models.SettingContentTitleMinChars, req.ContentTitleMinChars},
+		{models.SettingContentTitleMaxChars, req.ContentTitleMaxChars},
+		{models.SettingContentExcerptMaxWords, req.ContentExcerptMaxWords},
+		{models.SettingContentMinWords, req.ContentMinWords},
+		{models.SettingContentThumbnailMinWidth, req.ContentThumbnailMinWidth},
+		{models.SettingContentThumbnailMinHeight, req.ContentThumbnailMinHeight},
+		{models.SettingContentMaxLinks, req.ContentMaxLinks},
+		{models.SettingContentMinTags, req.ContentMinTags},
+	}
+	for _, setting := range intSettings {
+		if setting.value != nil && *setting.value < 0 {
+			apierror.Respond(c, apierror.BadRequest("setting_negative"))
+			return
+		}
+	}
+
+	before := h.currentSettings()
+	if req.RequireTwoFactor != nil {
+		if err := h.settingRepo.SetBool(models.SettingRequireTwoFactor, *req.RequireTwoFactor); err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+	}
+	if req.PublisherCreateTags != nil {
+		if err := h.settingRepo.SetBool(models.SettingPublisherCreateTags, *req.PublisherCreateTags); err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+	}
+	if req.RewardDailyCap != nil {
+		if err := h.settingRepo.SetMoney(models.SettingRewardDailyCap, *req.RewardDailyCap); err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+	}
+	if req.RewardMonthlyCap != nil {
+		if err := h.settingRepo.SetMoney(models.SettingRewardMonthlyCap, *req.RewardMonthlyCap); err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+	}
+
+	for _, setting := range intSettings {
+		if setting.value == nil {
+			continue
+		}
+		if err := h.settingRepo.SetInt(setting.key, *setting.value); err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+	}
+
+	if req.ContentBannedWords != nil {
+		if err := h.settingRepo.SetList(models.SettingContentBannedWords, req.ContentBannedWords); err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+	}
+
+	after := h.currentSettings()
+	recordAudit(c, "settings.update", models.AuditTargetSetting, 0, auditSnapshot(before), auditSnapshot(after))
+
+	c.JSON(http.StatusOK, gin.H{"data": after})
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"sort"
+
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+	"xinxun-news/internal/similarity"
+)
+
+const (
+	// similarityThreshold is the lowest score kept as a match
+	similarityThreshold = 0.3
+	// maxSimilarMatches is the number of matches stored on a submission
+	maxSimilarMatches = 3
+)
+
+// checkSimilarity compares the content of a submission with the stored
+// articles sharing a signature band with it and records its highest score
+// and closest matches on the news.
+// A revision is not compared with its original or the original's other
+// revisions. The news is not saved.
+func checkSimilarity(news *models.News) error {
+	signature := similarity.Signature(models.PlainText(news.Content))
+	news.ContentSignature = signature
+	news.SimilarityScore = 0
+	news.SimilarMatches = nil
+	if signature == "" {
+		return nil
+	}
+
+	candidates, err := repository.NewNewsRepository().FindSignatures(signature, news.ID, news.RevisionOf)
+	if err != nil {
+		return err
+	}
+
+	var matches models.SimilarMatches
+	for _, candidate := range candidates {
+		score := similarity.Compare(signature, candidate.ContentSignature)
+		if score > news.SimilarityScore {
+			news.SimilarityScore = score
+		}
+		if score < similarityThreshold {
+			continue
+		}
+		matches = append(matches, models.SimilarMatch{
+			NewsID: candidate.ID,
+			Title:  candidate.Title,
+			Slug:   candidate.Slug,
+			Status: string(candidate.Status),
+			Score:  score,
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].NewsID > matches[j].NewsID
+	})
+	if len(matches) > maxSimilarMatches {
+		matches = matches[:maxSimilarMatches]
+	}
+	news.SimilarMatches = matches
+	return nil
+}
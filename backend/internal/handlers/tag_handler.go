@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/blocks"
 	"xinxun-news/internal/database"
+	"xinxun-news/internal/dto"
 	"xinxun-news/internal/models"
 	"xinxun-news/internal/repository"
+	"xinxun-news/internal/tagsuggest"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gosimple/slug"
@@ -22,46 +27,162 @@ func NewTagHandler() *TagHandler {
 	}
 }
 
+// GetTags returns every tag in display order. Like categories it is not
+// paginated; large vocabularies are searched with the suggest endpoint.
 func (h *TagHandler) GetTags(c *gin.Context) {
 	tags, err := h.tagRepo.FindAll()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
+	lang, ok := parseContentLanguage(c)
+	if !ok {
+		return
+	}
+	localizeTags(tags, lang)
 
-	c.JSON(http.StatusOK, gin.H{"data": tags})
+	if models.UserType(userTypeString(c)).IsStaff() {
+		c.JSON(http.StatusOK, gin.H{"data": dto.NewAdminTags(tags)})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewPublicTags(tags)})
+}
+
+// GetTagBySlug returns one tag. Slugs of tags that were merged away
+// redirect permanently to the tag they were merged into.
+func (h *TagHandler) GetTagBySlug(c *gin.Context) {
+	lang, ok := parseContentLanguage(c)
+	if !ok {
+		return
+	}
+
+	tag, err := h.tagRepo.FindBySlug(c.Param("slug"))
+	if err != nil {
+		target, redirectErr := h.tagRepo.FindRedirect(c.Param("slug"))
+		if redirectErr != nil {
+			apierror.Respond(c, apierror.NotFound("tag_not_found"))
+			return
+		}
+		location := "/v1/tags/" + target.Slug
+		if c.Request.URL.RawQuery != "" {
+			location += "?" + c.Request.URL.RawQuery
+		}
+		c.Redirect(http.StatusMovedPermanently, location)
+		return
+	}
+
+	tags := []models.Tag{*tag}
+	localizeTags(tags, lang)
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewPublicTag(tags[0])})
+}
+
+// SuggestTags autocompletes tag names: ?q= matches names and slugs, best
+// prefix matches and most used tags first
+func (h *TagHandler) SuggestTags(c *gin.Context) {
+	lang, ok := parseContentLanguage(c)
+	if !ok {
+		return
+	}
+
+	query := normalizeTagName(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusOK, gin.H{"data": []dto.PublicTag{}})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 50 {
+		apierror.Respond(c, apierror.BadRequest("query_param_invalid", "limit"))
+		return
+	}
+
+	tags, err := h.tagRepo.Search(query, slug.Make(query), limit)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	localizeTags(tags, lang)
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewPublicTags(tags)})
+}
+
+type SuggestTagsForContentRequest struct {
+	Title   string               `json:"title"`
+	Content string               `json:"content"` // Raw HTML
+	Blocks  models.ContentBlocks `json:"blocks"`  // Used instead of Content when given
+	Limit   int                  `json:"limit"`   // Default 10
+}
+
+// SuggestTagsForContent proposes existing tags for an article being
+// written, based on the tag names found in its title and text
+func (h *TagHandler) SuggestTagsForContent(c *gin.Context) {
+	var req SuggestTagsForContentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+	if req.Limit <= 0 || req.Limit > 50 {
+		req.Limit = 10
+	}
+
+	text := blocks.HTMLText(req.Content)
+	if len(req.Blocks) > 0 {
+		text = blocks.PlainText(req.Blocks)
+	}
+
+	tags, err := h.tagRepo.FindAll()
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	suggestions := []dto.TagSuggestion{}
+	for _, suggestion := range tagsuggest.Suggest(tags, req.Title, text, req.Limit) {
+		suggestions = append(suggestions, dto.TagSuggestion{
+			PublicTag: dto.NewPublicTag(suggestion.Tag),
+			Score:     suggestion.Score,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"data": suggestions})
 }
 
 type CreateTagRequest struct {
-	Name  string `json:"name" binding:"required"`
-	Order int    `json:"order"` // Urutan tampilan (optional, default akan di-set otomatis)
+	Name  string                `json:"name" binding:"required"`
+	Names models.LocalizedNames `json:"names"` // Nama per bahasa (optional), mis. {"en": "Election"}
+	Order int                   `json:"order"` // Urutan tampilan (optional, default akan di-set otomatis)
 }
 
 func (h *TagHandler) CreateTag(c *gin.Context) {
 	var req CreateTagRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	if !validLocalizedNames(c, req.Names, "tag_names_unsupported") {
 		return
 	}
 
 	tagSlug := slug.Make(req.Name)
 	tag := &models.Tag{
 		Name:  req.Name,
+		Names: req.Names,
 		Slug:  tagSlug,
 		Order: req.Order,
 	}
 
 	if err := h.tagRepo.Create(tag); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"data": tag})
+	recordAudit(c, "tag.create", models.AuditTargetTag, tag.ID, nil, auditSnapshot(tag))
+
+	c.JSON(http.StatusCreated, gin.H{"data": dto.NewAdminTag(*tag)})
 }
 
 type UpdateTagRequest struct {
-	Name  string `json:"name"`
-	Order *int   `json:"order"` // Urutan tampilan (optional)
+	Name  string                `json:"name"`
+	Names models.LocalizedNames `json:"names"` // Menggantikan semua nama per bahasa bila diisi
+	Order *int                  `json:"order"` // Urutan tampilan (optional)
 }
 
 func (h *TagHandler) UpdateTag(c *gin.Context) {
@@ -69,31 +190,41 @@ func (h *TagHandler) UpdateTag(c *gin.Context) {
 
 	tag, err := h.tagRepo.FindByID(uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Tag tidak ditemukan"})
+		apierror.Respond(c, apierror.NotFound("tag_not_found"))
 		return
 	}
 
 	var req UpdateTagRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Bind(err))
 		return
 	}
+	before := auditSnapshot(tag)
 
 	if req.Name != "" {
 		tag.Name = req.Name
 		tag.Slug = slug.Make(req.Name)
 	}
 
+	if req.Names != nil {
+		if !validLocalizedNames(c, req.Names, "tag_names_unsupported") {
+			return
+		}
+		tag.Names = req.Names
+	}
+
 	if req.Order != nil {
 		tag.Order = *req.Order
 	}
 
 	if err := h.tagRepo.Update(tag); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": tag})
+	recordAudit(c, "tag.update", models.AuditTargetTag, tag.ID, before, auditSnapshot(tag))
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewAdminTag(*tag)})
 }
 
 func (h *TagHandler) DeleteTag(c *gin.Context) {
@@ -103,14 +234,184 @@ func (h *TagHandler) DeleteTag(c *gin.Context) {
 	var count int64
 	database.DB.Model(&models.News{}).Joins("JOIN news_tags ON news_tags.news_id = news.id").Where("news_tags.tag_id = ?", id).Count(&count)
 	if count > 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Tidak dapat menghapus tag dengan artikel yang ada"})
+		apierror.Respond(c, apierror.BadRequest("tag_in_use"))
 		return
 	}
 
+	var before map[string]interface{}
+	if tag, err := h.tagRepo.FindByID(uint(id)); err == nil {
+		before = auditSnapshot(tag)
+	}
+
 	if err := h.tagRepo.Delete(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
+	recordAudit(c, "tag.delete", models.AuditTargetTag, uint(id), before, nil)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Tag berhasil dihapus"})
 }
+
+// ReorderTags sets the display order from an ordered list of ids. Tags
+// left out of the list keep their relative order after the listed ones
+// (admin only).
+func (h *TagHandler) ReorderTags(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req IDListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	tags, err := h.tagRepo.FindAll()
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	previous := make(map[uint]int, len(tags))
+	existing := make(map[uint]bool, len(tags))
+	for _, tag := range tags {
+		previous[tag.ID] = tag.Order
+		existing[tag.ID] = true
+	}
+	if !validOrderIDs(c, req.IDs, existing) {
+		return
+	}
+
+	if err := h.tagRepo.Reorder(req.IDs); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	tags, err = h.tagRepo.FindAll()
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	for _, tag := range tags {
+		if tag.Order != previous[tag.ID] {
+			recordAudit(c, "tag.reorder", models.AuditTargetTag, tag.ID,
+				map[string]interface{}{"order": previous[tag.ID]},
+				map[string]interface{}{"order": tag.Order})
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewAdminTags(tags)})
+}
+
+type MergeTagsRequest struct {
+	SourceIDs []uint `json:"source_ids" binding:"required,min=1"` // Tag yang digabung ke tag tujuan
+}
+
+// MergeTags merges duplicate tags into the tag in the URL. Articles of the
+// source tags get the target tag, the source tags are deleted and their
+// slugs redirect to the target (admin only).
+func (h *TagHandler) MergeTags(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	target, err := h.tagRepo.FindByID(uint(id))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("tag_not_found"))
+		return
+	}
+
+	var req MergeTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	var sources []models.Tag
+	seen := map[uint]bool{}
+	for _, sourceID := range req.SourceIDs {
+		if seen[sourceID] {
+			continue
+		}
+		seen[sourceID] = true
+		if sourceID == target.ID {
+			apierror.Respond(c, apierror.BadRequest("tag_merge_into_self"))
+			return
+		}
+		source, err := h.tagRepo.FindByID(sourceID)
+		if err != nil {
+			apierror.Respond(c, apierror.NotFound("tag_not_found").With("id", sourceID))
+			return
+		}
+		sources = append(sources, *source)
+	}
+
+	moved, err := h.tagRepo.Merge(sources, target.ID)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	for i := range sources {
+		recordAudit(c, "tag.merge", models.AuditTargetTag, sources[i].ID,
+			auditSnapshot(&sources[i]), map[string]interface{}{"merged_into": target.ID})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    fmt.Sprintf("%d tag berhasil digabung ke %s", len(sources), target.Name),
+		"data":       dto.NewAdminTag(*target),
+		"moved_news": moved,
+		"redirects":  len(sources),
+	})
+}
+
+// BulkDeleteTags deletes several tags at once. Tags that are still used by
+// articles are skipped and reported in "blocked" (admin only).
+func (h *TagHandler) BulkDeleteTags(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req IDListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	deleted := []uint{}
+	blocked := []BlockedItem{}
+	seen := map[uint]bool{}
+	for _, id := range req.IDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		tag, err := h.tagRepo.FindByID(id)
+		if err != nil {
+			blocked = append(blocked, newBlockedItem(c, id, "tag_not_found"))
+			continue
+		}
+		var count int64
+		database.DB.Model(&models.News{}).Joins("JOIN news_tags ON news_tags.news_id = news.id").Where("news_tags.tag_id = ?", id).Count(&count)
+		if count > 0 {
+			blocked = append(blocked, newBlockedItem(c, id, "tag_in_use"))
+			continue
+		}
+
+		if err := h.tagRepo.Delete(id); err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+		recordAudit(c, "tag.delete", models.AuditTargetTag, id, auditSnapshot(tag), nil)
+		deleted = append(deleted, id)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": fmt.Sprintf("%d tag berhasil dihapus", len(deleted)),
+		"data": gin.H{
+			"deleted": deleted,
+			"blocked": blocked,
+		},
+	})
+}
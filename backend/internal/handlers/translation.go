@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/config"
+	"xinxun-news/internal/dto"
+	"xinxun-news/internal/i18n"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// contentLanguage returns the normalized lang query parameter used to
+// filter public content, or an empty string when it is not set
+func contentLanguage(c *gin.Context) string {
+	return i18n.Normalize(c.Query("lang"))
+}
+
+// parseContentLanguage reads the lang query parameter. It responds 400 and
+// returns false when articles cannot be written in the requested language.
+func parseContentLanguage(c *gin.Context) (string, bool) {
+	raw := c.Query("lang")
+	if raw == "" {
+		return "", true
+	}
+	lang := i18n.Normalize(raw)
+	if !supportedContentLanguage(lang) {
+		apierror.Respond(c, apierror.BadRequest("language_unsupported", raw))
+		return "", false
+	}
+	return lang, true
+}
+
+// supportedContentLanguage reports whether articles can be written in lang
+func supportedContentLanguage(lang string) bool {
+	if lang == i18n.Default {
+		return true
+	}
+	for _, supported := range config.AppConfig.ContentLanguages {
+		if i18n.Normalize(supported) == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// validLocalizedNames responds 400 with code and returns false when names
+// contains a language articles cannot be written in
+func validLocalizedNames(c *gin.Context, names models.LocalizedNames, code string) bool {
+	for lang := range names {
+		if i18n.Normalize(lang) != lang || !supportedContentLanguage(lang) {
+			apierror.Respond(c, apierror.BadRequest(code, lang))
+			return false
+		}
+	}
+	return true
+}
+
+// applyTranslation sets the language of news and its translation group
+// from a create or update request. lang is left unchanged when empty.
+// translationOf links news to the translation group of another article;
+// 0 removes it from its group. selfID is the article news is saved as (the
+// original for revisions, 0 for new articles). The news is not saved. It
+// responds and returns false when the change is not allowed.
+func applyTranslation(c *gin.Context, news *models.News, selfID uint, lang string, translationOf *uint) bool {
+	languageChanged := false
+	if lang != "" {
+		normalized := i18n.Normalize(lang)
+		if !supportedContentLanguage(normalized) {
+			apierror.Respond(c, apierror.BadRequest("language_unsupported", lang))
+			return false
+		}
+		languageChanged = normalized != news.Language
+		news.Language = normalized
+	}
+
+	switch {
+	case translationOf != nil && *translationOf == 0:
+		news.TranslationGroupID = nil
+	case translationOf != nil:
+		return linkTranslation(c, news, selfID, *translationOf)
+	case languageChanged && news.TranslationGroupID != nil:
+		return translationLanguageFree(c, *news.TranslationGroupID, news.Language, selfID, nil)
+	}
+	return true
+}
+
+// linkTranslation puts news in the translation group of the source article,
+// creating the group when the source has none. Publishers can only
+// translate their own articles.
+func linkTranslation(c *gin.Context, news *models.News, selfID uint, sourceID uint) bool {
+	newsRepo := repository.NewNewsRepository()
+
+	if selfID != 0 && sourceID == selfID {
+		apierror.Respond(c, apierror.BadRequest("translation_self"))
+		return false
+	}
+	source, err := newsRepo.FindByID(sourceID)
+	if err != nil {
+		apierror.Respond(c, apierror.BadRequest("translation_source_not_found"))
+		return false
+	}
+	if userTypeString(c) == string(models.UserTypePublisher) && source.AuthorID != news.AuthorID {
+		apierror.Respond(c, apierror.Forbidden("translation_source_forbidden"))
+		return false
+	}
+	if source.RevisionOf != nil {
+		apierror.Respond(c, apierror.BadRequest("translation_source_revision"))
+		return false
+	}
+
+	groupID := source.ID
+	if source.TranslationGroupID != nil {
+		groupID = *source.TranslationGroupID
+	}
+	if !translationLanguageFree(c, groupID, news.Language, selfID, source) {
+		return false
+	}
+
+	if source.TranslationGroupID == nil {
+		if err := newsRepo.SetTranslationGroup(source.ID, &groupID); err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return false
+		}
+	}
+	news.TranslationGroupID = &groupID
+	return true
+}
+
+// translationLanguageFree checks that no other article of a translation
+// group is written in lang. source is an article of the group that may not
+// be stored with the group yet. It responds and returns false when taken.
+func translationLanguageFree(c *gin.Context, groupID uint, lang string, excludeID uint, source *models.News) bool {
+	members, err := repository.NewNewsRepository().FindTranslations(groupID)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return false
+	}
+	if source != nil {
+		members = append(members, *source)
+	}
+	for _, member := range members {
+		if member.ID != excludeID && member.Language == lang {
+			code := "translation_language_taken"
+			if source != nil && member.ID == source.ID {
+				code = "translation_same_language"
+			}
+			apierror.Respond(c, apierror.Conflict(code, lang))
+			return false
+		}
+	}
+	return true
+}
+
+// newsTranslations lists the published articles of the news' translation
+// group, itself included, as hreflang alternates
+func newsTranslations(news *models.News) ([]dto.NewsTranslation, error) {
+	if news.TranslationGroupID == nil {
+		return []dto.NewsTranslation{}, nil
+	}
+	members, err := repository.NewNewsRepository().FindTranslations(*news.TranslationGroupID)
+	if err != nil {
+		return nil, err
+	}
+	translations := make([]dto.NewsTranslation, 0, len(members))
+	for _, member := range members {
+		if member.Status != models.StatusPublished {
+			continue
+		}
+		translations = append(translations, dto.NewsTranslation{
+			ID:       member.ID,
+			Language: member.Language,
+			Title:    member.Title,
+			Slug:     member.Slug,
+			URL:      config.AppConfig.AppURL + "/" + member.Slug,
+		})
+	}
+	return translations, nil
+}
+
+// localizeNews replaces the category and tag names of articles with their
+// names in lang, when they have one
+func localizeNews(news []models.News, lang string) {
+	if lang == "" {
+		return
+	}
+	for i := range news {
+		news[i].Category.Name = news[i].Category.Names.Get(lang, news[i].Category.Name)
+		localizeTags(news[i].Tags, lang)
+	}
+}
+
+func localizeCategories(categories []models.Category, lang string) {
+	if lang == "" {
+		return
+	}
+	for i := range categories {
+		categories[i].Name = categories[i].Names.Get(lang, categories[i].Name)
+	}
+}
+
+func localizeTags(tags []models.Tag, lang string) {
+	if lang == "" {
+		return
+	}
+	for i := range tags {
+		tags[i].Name = tags[i].Names.Get(lang, tags[i].Name)
+	}
+}
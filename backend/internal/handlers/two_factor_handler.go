@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+	"xinxun-news/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	twoFactorIssuer   = "Xinxun News"
+	recoveryCodeCount = 10
+)
+
+type TwoFactorHandler struct {
+	userRepo     *repository.UserRepository
+	recoveryRepo *repository.RecoveryCodeRepository
+	settingRepo  *repository.SettingRepository
+}
+
+func NewTwoFactorHandler() *TwoFactorHandler {
+	return &TwoFactorHandler{
+		userRepo:     repository.NewUserRepository(),
+		recoveryRepo: repository.NewRecoveryCodeRepository(),
+		settingRepo:  repository.NewSettingRepository(),
+	}
+}
+
+// currentStaff loads the authenticated admin/editor, writing the error
+// response when the account is missing or not staff
+func (h *TwoFactorHandler) currentStaff(c *gin.Context) (*models.User, bool) {
+	userID, _ := c.Get("user_id")
+
+	user, err := h.userRepo.FindByID(userID.(uint))
+	if err != nil {
+		apierror.Respond(c, apierror.NotFound("user_not_found"))
+		return nil, false
+	}
+	if !user.UserType.IsStaff() {
+		apierror.Respond(c, apierror.Forbidden("two_factor_staff_only"))
+		return nil, false
+	}
+	return user, true
+}
+
+// GetStatus returns whether 2FA is enabled, required, and how many recovery
+// codes are left
+func (h *TwoFactorHandler) GetStatus(c *gin.Context) {
+	user, ok := h.currentStaff(c)
+	if !ok {
+		return
+	}
+
+	remaining, err := h.recoveryRepo.CountUnused(user.ID)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"enabled":                  user.TwoFactorEnabled,
+		"required":                 h.settingRepo.GetBool(models.SettingRequireTwoFactor, false),
+		"recovery_codes_remaining": remaining,
+	})
+}
+
+// Setup generates a new TOTP secret. 2FA stays disabled until Enable
+// confirms a code from the authenticator app.
+func (h *TwoFactorHandler) Setup(c *gin.Context) {
+	user, ok := h.currentStaff(c)
+	if !ok {
+		return
+	}
+	if user.TwoFactorEnabled {
+		apierror.Respond(c, apierror.BadRequest("two_factor_already_enabled"))
+		return
+	}
+
+	secret, err := services.GenerateTOTPSecret()
+	if err != nil {
+		apierror.Respond(c, apierror.New(http.StatusInternalServerError, "two_factor_secret_failed").Wrap(err))
+		return
+	}
+
+	user.TwoFactorSecret = secret
+	user.TwoFactorLastStep = 0
+	if err := h.userRepo.Update(user); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"secret":      secret,
+		"otpauth_url": services.TOTPURL(twoFactorIssuer, user.Username, secret),
+	})
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// Enable confirms enrollment with a code from the authenticator app and
+// returns the recovery codes. They are shown only once.
+func (h *TwoFactorHandler) Enable(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	user, ok := h.currentStaff(c)
+	if !ok {
+		return
+	}
+	if user.TwoFactorEnabled {
+		apierror.Respond(c, apierror.BadRequest("two_factor_already_enabled"))
+		return
+	}
+	if user.TwoFactorSecret == "" {
+		apierror.Respond(c, apierror.BadRequest("two_factor_setup_missing"))
+		return
+	}
+
+	step, valid := services.VerifyTOTP(user.TwoFactorSecret, req.Code, user.TwoFactorLastStep, time.Now())
+	if !valid {
+		apierror.Respond(c, apierror.BadRequest("two_factor_code_invalid"))
+		return
+	}
+
+	codes, err := h.issueRecoveryCodes(user.ID)
+	if err != nil {
+		apierror.Respond(c, apierror.New(http.StatusInternalServerError, "recovery_code_generation_failed").Wrap(err))
+		return
+	}
+
+	user.TwoFactorEnabled = true
+	user.TwoFactorLastStep = step
+	if err := h.userRepo.Update(user); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	// The current token may still carry must_setup_two_factor
+	token, err := services.GenerateToken(user.ID, string(user.UserType), user.TokenVersion, services.TokenFlags{
+		MustChangePassword: user.MustChangePassword,
+	})
+	if err != nil {
+		apierror.Respond(c, apierror.New(http.StatusInternalServerError, "token_generation_failed").Wrap(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "2FA berhasil diaktifkan",
+		"recovery_codes": codes,
+		"token":          token,
+	})
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// Disable turns 2FA off after re-checking the password and a current code.
+// It is refused while the admin setting requires 2FA.
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	var req DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	user, ok := h.currentStaff(c)
+	if !ok {
+		return
+	}
+	if !user.TwoFactorEnabled {
+		apierror.Respond(c, apierror.BadRequest("two_factor_not_enabled"))
+		return
+	}
+	if h.settingRepo.GetBool(models.SettingRequireTwoFactor, false) {
+		apierror.Respond(c, apierror.Forbidden("two_factor_required_by_policy"))
+		return
+	}
+	if !services.CheckPasswordHash(req.Password, user.PasswordHash) {
+		apierror.Respond(c, apierror.Unauthorized("password_incorrect"))
+		return
+	}
+
+	valid, err := verifySecondFactor(h.userRepo, h.recoveryRepo, user, req.Code, "")
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	if !valid {
+		apierror.Respond(c, apierror.BadRequest("two_factor_code_invalid"))
+		return
+	}
+
+	user.TwoFactorEnabled = false
+	user.TwoFactorSecret = ""
+	user.TwoFactorLastStep = 0
+	if err := h.userRepo.Update(user); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	if err := h.recoveryRepo.DeleteForUser(user.ID); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "2FA berhasil dinonaktifkan"})
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes after checking a
+// current TOTP code
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	user, ok := h.currentStaff(c)
+	if !ok {
+		return
+	}
+	if !user.TwoFactorEnabled {
+		apierror.Respond(c, apierror.BadRequest("two_factor_not_enabled"))
+		return
+	}
+
+	valid, err := verifySecondFactor(h.userRepo, h.recoveryRepo, user, req.Code, "")
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+	if !valid {
+		apierror.Respond(c, apierror.BadRequest("two_factor_code_invalid"))
+		return
+	}
+
+	codes, err := h.issueRecoveryCodes(user.ID)
+	if err != nil {
+		apierror.Respond(c, apierror.New(http.StatusInternalServerError, "recovery_code_generation_failed").Wrap(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+// issueRecoveryCodes replaces the user's recovery codes and returns the
+// plaintext codes. Only their hashes are stored.
+func (h *TwoFactorHandler) issueRecoveryCodes(userID uint) ([]string, error) {
+	codes, err := services.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = services.HashOpaqueToken(services.NormalizeRecoveryCode(code))
+	}
+	if err := h.recoveryRepo.Replace(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// verifySecondFactor checks a TOTP code, or a recovery code when one is
+// given, and persists the state that prevents reuse
+func verifySecondFactor(userRepo *repository.UserRepository, recoveryRepo *repository.RecoveryCodeRepository, user *models.User, code, recoveryCode string) (bool, error) {
+	if recoveryCode != "" {
+		hash := services.HashOpaqueToken(services.NormalizeRecoveryCode(recoveryCode))
+		return recoveryRepo.Use(user.ID, hash)
+	}
+
+	step, valid := services.VerifyTOTP(user.TwoFactorSecret, code, user.TwoFactorLastStep, time.Now())
+	if !valid {
+		return false, nil
+	}
+
+	user.TwoFactorLastStep = step
+	if err := userRepo.Update(user); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// twoFactorSetupRequired reports whether a staff account must enroll in 2FA
+// before using the admin panel
+func twoFactorSetupRequired(settingRepo *repository.SettingRepository, user *models.User) bool {
+	return user.UserType.IsStaff() && !user.TwoFactorEnabled &&
+		settingRepo.GetBool(models.SettingRequireTwoFactor, false)
+}
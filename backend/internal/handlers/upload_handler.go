@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"xinxun-news/internal/apierror"
 	"xinxun-news/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -28,7 +29,7 @@ func UploadImage(c *gin.Context) {
 	file, err := c.FormFile("image")
 	if err != nil {
 		log.Printf("[UploadImage] ERROR at Step 1 - Error getting file from form: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Tidak ada file gambar yang diberikan"})
+		apierror.Respond(c, apierror.BadRequest("image_required"))
 		return
 	}
 	log.Printf("[UploadImage] Step 1 SUCCESS - File received: %s, Size: %d bytes", file.Filename, file.Size)
@@ -37,7 +38,7 @@ func UploadImage(c *gin.Context) {
 	maxSize := int64(10 * 1024 * 1024) // 10MB
 	if file.Size > maxSize {
 		log.Printf("[UploadImage] ERROR - File too large: %d bytes (max: %d bytes)", file.Size, maxSize)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File terlalu besar. Maksimal 10MB"})
+		apierror.Respond(c, apierror.BadRequest("file_too_large"))
 		return
 	}
 
@@ -46,7 +47,7 @@ func UploadImage(c *gin.Context) {
 	src, err := file.Open()
 	if err != nil {
 		log.Printf("[UploadImage] ERROR at Step 2 - Error opening file: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal membuka file"})
+		apierror.Respond(c, apierror.New(http.StatusInternalServerError, "upload_read_failed").Wrap(err))
 		return
 	}
 	defer func() {
@@ -63,7 +64,7 @@ func UploadImage(c *gin.Context) {
 	readDuration := time.Since(readStart)
 	if err != nil {
 		log.Printf("[UploadImage] ERROR at Step 3 - Error reading file data: %v (took %v)", err, readDuration)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal membaca data file"})
+		apierror.Respond(c, apierror.New(http.StatusInternalServerError, "upload_read_failed").Wrap(err))
 		return
 	}
 	log.Printf("[UploadImage] Step 3 SUCCESS - File data read: %d bytes (took %v)", len(fileData), readDuration)
@@ -118,9 +119,7 @@ func UploadImage(c *gin.Context) {
 		uploadDuration := time.Since(uploadStart)
 		if result.err != nil {
 			log.Printf("[UploadImage] ERROR at Step 5 - S3 upload failed: %v (took %v)", result.err, uploadDuration)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": fmt.Sprintf("Gagal mengupload ke S3: %v", result.err),
-			})
+			apierror.Respond(c, apierror.New(http.StatusInternalServerError, "upload_failed").Wrap(result.err))
 			return
 		}
 		log.Printf("[UploadImage] Step 5 SUCCESS - S3 upload completed. URL: %s (took %v)", result.url, uploadDuration)
@@ -136,9 +135,7 @@ func UploadImage(c *gin.Context) {
 	case <-ctx.Done():
 		uploadDuration := time.Since(uploadStart)
 		log.Printf("[UploadImage] ERROR at Step 5 - S3 upload timeout after %v", uploadDuration)
-		c.JSON(http.StatusRequestTimeout, gin.H{
-			"error": "Upload timeout. Silakan coba lagi dengan file yang lebih kecil.",
-		})
+		apierror.Respond(c, apierror.New(http.StatusRequestTimeout, "upload_timeout"))
 		return
 	}
 }
@@ -1,10 +1,15 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
+	"xinxun-news/internal/apierror"
 	"xinxun-news/internal/database"
+	"xinxun-news/internal/dto"
 	"xinxun-news/internal/models"
 	"xinxun-news/internal/repository"
 	"xinxun-news/internal/services"
@@ -23,24 +28,45 @@ func NewUserHandler() *UserHandler {
 }
 
 type UpdateProfileRequest struct {
-	Username string `json:"username"`
-	Name     string `json:"name"`
-	Password string `json:"password"`
+	Username    string             `json:"username"`
+	Name        string             `json:"name"`
+	Password    string             `json:"password"`
+	DisplayName *string            `json:"display_name"`
+	Bio         *string            `json:"bio"`
+	AvatarURL   *string            `json:"avatar_url"`
+	SocialLinks models.SocialLinks `json:"social_links"`
 }
 
-// UpdateProfile updates current user's profile (username, name, password)
+// allowedSocialNetworks lists the keys accepted in social_links
+var allowedSocialNetworks = map[string]bool{
+	"website":   true,
+	"twitter":   true,
+	"facebook":  true,
+	"instagram": true,
+	"linkedin":  true,
+	"youtube":   true,
+	"tiktok":    true,
+}
+
+// UpdateProfile updates current user's profile (username, name, password and public author fields)
 func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	userID, _ := c.Get("user_id")
 
 	user, err := h.userRepo.FindByID(userID.(uint))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User tidak ditemukan"})
+		apierror.Respond(c, apierror.NotFound("user_not_found"))
 		return
 	}
 
 	var req UpdateProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	passwordChangeRequired := user.MustChangePassword
+	if passwordChangeRequired && req.Password == "" {
+		apierror.Respond(c, apierror.BadRequest("password_change_required"))
 		return
 	}
 
@@ -48,7 +74,7 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 		// Check if username already exists (except current user)
 		existing, _ := h.userRepo.FindByUsername(req.Username)
 		if existing != nil && existing.ID != user.ID {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Username sudah ada"})
+			apierror.Respond(c, apierror.BadRequest("username_taken"))
 			return
 		}
 		user.Username = req.Username
@@ -60,32 +86,83 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 
 	if req.Password != "" {
 		if len(req.Password) < 6 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Password harus minimal 6 karakter"})
+			apierror.Respond(c, apierror.BadRequest("password_too_short"))
 			return
 		}
 		hashedPassword, err := services.HashPassword(req.Password)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal menghash password"})
+			apierror.Respond(c, apierror.New(http.StatusInternalServerError, "password_hash_failed").Wrap(err))
 			return
 		}
-		user.PasswordHash = hashedPassword
+		user.SetPassword(hashedPassword)
+		user.MustChangePassword = false
+	}
+
+	if req.DisplayName != nil {
+		user.DisplayName = strings.TrimSpace(*req.DisplayName)
+	}
+
+	if req.Bio != nil {
+		if len([]rune(*req.Bio)) > 1000 {
+			apierror.Respond(c, apierror.BadRequest("bio_too_long"))
+			return
+		}
+		user.Bio = strings.TrimSpace(*req.Bio)
+	}
+
+	if req.AvatarURL != nil {
+		avatarURL := strings.TrimSpace(*req.AvatarURL)
+		if avatarURL != "" && !isHTTPURL(avatarURL) {
+			apierror.Respond(c, apierror.BadRequest("avatar_url_invalid"))
+			return
+		}
+		user.AvatarURL = avatarURL
+	}
+
+	if req.SocialLinks != nil {
+		links := models.SocialLinks{}
+		for network, link := range req.SocialLinks {
+			if !allowedSocialNetworks[network] {
+				apierror.Respond(c, apierror.BadRequest("social_link_unsupported", network))
+				return
+			}
+			link = strings.TrimSpace(link)
+			if link == "" {
+				continue
+			}
+			if !isHTTPURL(link) {
+				apierror.Respond(c, apierror.BadRequest("social_link_invalid", network))
+				return
+			}
+			links[network] = link
+		}
+		user.SocialLinks = links
 	}
 
 	if err := h.userRepo.Update(user); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"message": "Profile berhasil diupdate",
-		"data": gin.H{
-			"id":        user.ID,
-			"username":  user.Username,
-			"name":      user.Name,
-			"email":     user.Email,
-			"user_type": user.UserType,
-		},
-	})
+		"data":    dto.NewPublisherUser(*user),
+	}
+
+	// Changing the password revoked the old token, and it may still carry
+	// must_change_password, so hand out a fresh one
+	if req.Password != "" {
+		token, err := services.GenerateToken(user.ID, string(user.UserType), user.TokenVersion, services.TokenFlags{
+			MustSetupTwoFactor: c.GetBool("must_setup_two_factor"),
+		})
+		if err != nil {
+			apierror.Respond(c, apierror.New(http.StatusInternalServerError, "token_generation_failed").Wrap(err))
+			return
+		}
+		response["token"] = token
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // GetProfile gets current user's profile
@@ -94,25 +171,11 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 
 	user, err := h.userRepo.FindByID(userID.(uint))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User tidak ditemukan"})
+		apierror.Respond(c, apierror.NotFound("user_not_found"))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data": gin.H{
-			"id":            user.ID,
-			"username":      user.Username,
-			"name":          user.Name,
-			"email":         user.Email,
-			"user_type":     user.UserType,
-			"xinxun_id":     user.XinxunID,
-			"xinxun_number": user.XinxunNumber,
-			"balance":       user.Balance,
-			"status":        user.Status,
-			"reff_code":     user.ReffCode,
-			"created_at":    user.CreatedAt,
-		},
-	})
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewPublisherUser(*user)})
 }
 
 // GetAllPublishers gets all publishers (admin only)
@@ -120,20 +183,24 @@ func (h *UserHandler) GetAllPublishers(c *gin.Context) {
 	// Get user type to check if admin
 	userType, exists := c.Get("user_type")
 	if !exists || userType != string(models.UserTypeAdmin) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Akses admin diperlukan"})
+		apierror.Respond(c, apierror.Forbidden("admin_required"))
+		return
+	}
+
+	params, ok := parseListParams(c, 50)
+	if !ok {
 		return
 	}
 
-	// Get all publishers
-	var publishers []models.User
-	err := database.DB.Where("user_type = ?", models.UserTypePublisher).Find(&publishers).Error
+	publishers, total, next, err := h.userRepo.FindPublishers(params.Limit, params.Offset(), params.Cursor)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data": publishers,
+		"data": dto.NewAdminUsers(publishers),
+		"meta": listMeta(total, next, params),
 	})
 }
 
@@ -142,31 +209,34 @@ func (h *UserHandler) GetPublisher(c *gin.Context) {
 	// Check if admin
 	userType, exists := c.Get("user_type")
 	if !exists || userType != string(models.UserTypeAdmin) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Akses admin diperlukan"})
+		apierror.Respond(c, apierror.Forbidden("admin_required"))
 		return
 	}
 
 	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 	publisher, err := h.userRepo.FindByID(uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Publisher tidak ditemukan"})
+		apierror.Respond(c, apierror.NotFound("publisher_not_found"))
 		return
 	}
 
 	if publisher.UserType != models.UserTypePublisher {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "User tidak adalah publisher"})
+		apierror.Respond(c, apierror.BadRequest("user_not_publisher"))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": publisher})
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewAdminUser(*publisher)})
 }
 
 type UpdatePublisherRequest struct {
-	Username string  `json:"username"`
-	Name     string  `json:"name"`
-	Password string  `json:"password"`
-	Status   string  `json:"status"`
-	Balance  float64 `json:"balance"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+	Status   string `json:"status"`
+	// Balance sets a new balance; the difference is recorded in the ledger
+	// as an adjustment with BalanceReason
+	Balance       *models.Money `json:"balance"`
+	BalanceReason string        `json:"balance_reason"`
 }
 
 // UpdatePublisher updates a publisher (admin only)
@@ -174,33 +244,34 @@ func (h *UserHandler) UpdatePublisher(c *gin.Context) {
 	// Check if admin
 	userType, exists := c.Get("user_type")
 	if !exists || userType != string(models.UserTypeAdmin) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Akses admin diperlukan"})
+		apierror.Respond(c, apierror.Forbidden("admin_required"))
 		return
 	}
 
 	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 	publisher, err := h.userRepo.FindByID(uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Publisher tidak ditemukan"})
+		apierror.Respond(c, apierror.NotFound("publisher_not_found"))
 		return
 	}
 
 	if publisher.UserType != models.UserTypePublisher {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "User tidak adalah publisher"})
+		apierror.Respond(c, apierror.BadRequest("user_not_publisher"))
 		return
 	}
 
 	var req UpdatePublisherRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Bind(err))
 		return
 	}
+	before := auditSnapshot(publisher)
 
 	if req.Username != "" {
 		// Check if username already exists (except current user)
 		existing, _ := h.userRepo.FindByUsername(req.Username)
 		if existing != nil && existing.ID != publisher.ID {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Username sudah ada"})
+			apierror.Respond(c, apierror.BadRequest("username_taken"))
 			return
 		}
 		publisher.Username = req.Username
@@ -212,33 +283,56 @@ func (h *UserHandler) UpdatePublisher(c *gin.Context) {
 
 	if req.Password != "" {
 		if len(req.Password) < 6 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Password harus minimal 6 karakter"})
+			apierror.Respond(c, apierror.BadRequest("password_too_short"))
 			return
 		}
 		hashedPassword, err := services.HashPassword(req.Password)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal menghash password"})
+			apierror.Respond(c, apierror.New(http.StatusInternalServerError, "password_hash_failed").Wrap(err))
 			return
 		}
-		publisher.PasswordHash = hashedPassword
+		publisher.SetPassword(hashedPassword)
 	}
 
 	if req.Status != "" {
 		publisher.Status = req.Status
 	}
 
-	if req.Balance >= 0 {
-		publisher.Balance = req.Balance
+	if req.Balance != nil && *req.Balance < 0 {
+		apierror.Respond(c, apierror.BadRequest("balance_negative"))
+		return
+	}
+
+	// The ledger, not the users.balance column, is the source of the balance
+	balance, err := repository.NewBalanceRepository().Balance(publisher.ID)
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
 	}
 
 	if err := h.userRepo.Update(publisher); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
 
+	if req.Balance != nil && *req.Balance != balance {
+		reason := req.BalanceReason
+		if reason == "" {
+			reason = "Penyesuaian saldo oleh admin"
+		}
+		entry, err := recordBalanceAdjustment(c, publisher.ID, *req.Balance-balance, reason)
+		if err != nil {
+			apierror.Respond(c, apierror.Internal(err))
+			return
+		}
+		publisher.Balance = entry.BalanceAfter
+	}
+
+	recordAudit(c, "publisher.update", models.AuditTargetUser, publisher.ID, before, auditSnapshot(publisher))
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Publisher berhasil diupdate",
-		"data":    publisher,
+		"data":    dto.NewAdminUser(*publisher),
 	})
 }
 
@@ -247,27 +341,325 @@ func (h *UserHandler) DeletePublisher(c *gin.Context) {
 	// Check if admin
 	userType, exists := c.Get("user_type")
 	if !exists || userType != string(models.UserTypeAdmin) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Akses admin diperlukan"})
+		apierror.Respond(c, apierror.Forbidden("admin_required"))
 		return
 	}
 
 	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
 	publisher, err := h.userRepo.FindByID(uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Publisher tidak ditemukan"})
+		apierror.Respond(c, apierror.NotFound("publisher_not_found"))
 		return
 	}
 
 	if publisher.UserType != models.UserTypePublisher {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "User tidak adalah publisher"})
+		apierror.Respond(c, apierror.BadRequest("user_not_publisher"))
 		return
 	}
 
 	// Soft delete
 	if err := database.DB.Delete(publisher).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.Internal(err))
 		return
 	}
+	recordAudit(c, "publisher.delete", models.AuditTargetUser, publisher.ID, auditSnapshot(publisher), nil)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Publisher berhasil dihapus"})
 }
+
+// isHTTPURL reports whether value is an absolute http(s) URL
+func isHTTPURL(value string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// requireAdmin writes a 403 response and returns false unless the caller is an admin
+func requireAdmin(c *gin.Context) bool {
+	if userTypeString(c) != string(models.UserTypeAdmin) {
+		apierror.Respond(c, apierror.Forbidden("admin_required"))
+		return false
+	}
+	return true
+}
+
+// findStaff loads an admin/editor account from the :id parameter, writing
+// the error response when it does not exist
+func (h *UserHandler) findStaff(c *gin.Context) (*models.User, bool) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	user, err := h.userRepo.FindByID(uint(id))
+	if err != nil || !user.UserType.IsStaff() {
+		apierror.Respond(c, apierror.NotFound("user_not_found"))
+		return nil, false
+	}
+	return user, true
+}
+
+// respondStaffSaveError responds to a failed UpdateKeepingAdmin or
+// DeleteKeepingAdmin
+func respondStaffSaveError(c *gin.Context, err error) {
+	if errors.Is(err, repository.ErrLastActiveAdmin) {
+		apierror.Respond(c, apierror.BadRequest("last_active_admin"))
+		return
+	}
+	apierror.Respond(c, apierror.Internal(err))
+}
+
+// GetStaffUsers lists admin and editor accounts (admin only). The list is
+// short, so it is returned whole.
+func (h *UserHandler) GetStaffUsers(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	users, err := h.userRepo.FindStaff()
+	if err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewAdminUsers(users)})
+}
+
+// GetStaffUser gets a single admin/editor account (admin only)
+func (h *UserHandler) GetStaffUser(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	user, ok := h.findStaff(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": dto.NewAdminUser(*user)})
+}
+
+type CreateStaffUserRequest struct {
+	Username string          `json:"username" binding:"required"`
+	Name     string          `json:"name" binding:"required"`
+	Email    string          `json:"email" binding:"required,email"`
+	Password string          `json:"password" binding:"required,min=6"`
+	UserType models.UserType `json:"user_type" binding:"required"`
+}
+
+// CreateStaffUser creates an admin or editor account (admin only).
+// The account must change the given password on first login.
+func (h *UserHandler) CreateStaffUser(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req CreateStaffUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+
+	if !req.UserType.IsStaff() {
+		apierror.Respond(c, apierror.BadRequest("staff_type_invalid"))
+		return
+	}
+
+	if existing, err := h.userRepo.FindByUsername(req.Username); err == nil && existing.ID != 0 {
+		apierror.Respond(c, apierror.BadRequest("username_taken"))
+		return
+	}
+	if existing, err := h.userRepo.FindByEmail(req.Email); err == nil && existing.ID != 0 {
+		apierror.Respond(c, apierror.BadRequest("email_taken"))
+		return
+	}
+
+	hashedPassword, err := services.HashPassword(req.Password)
+	if err != nil {
+		apierror.Respond(c, apierror.New(http.StatusInternalServerError, "password_hash_failed").Wrap(err))
+		return
+	}
+
+	user := &models.User{
+		Username:           req.Username,
+		Name:               req.Name,
+		Email:              req.Email,
+		PasswordHash:       hashedPassword,
+		UserType:           req.UserType,
+		Status:             models.UserStatusActive,
+		MustChangePassword: true,
+	}
+
+	if err := h.userRepo.Create(user); err != nil {
+		apierror.Respond(c, apierror.Internal(err))
+		return
+	}
+
+	sendVerificationEmailAsync(*user)
+	recordAudit(c, "staff.create", models.AuditTargetUser, user.ID, nil, auditSnapshot(user))
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "User berhasil dibuat",
+		"data":    dto.NewAdminUser(*user),
+	})
+}
+
+type UpdateStaffUserRequest struct {
+	Username string          `json:"username"`
+	Name     string          `json:"name"`
+	Email    string          `json:"email"`
+	Password string          `json:"password"`
+	UserType models.UserType `json:"user_type"`
+}
+
+// UpdateStaffUser updates an admin/editor account (admin only). Setting a
+// password forces the user to change it again on next login.
+func (h *UserHandler) UpdateStaffUser(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	user, ok := h.findStaff(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateStaffUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.Bind(err))
+		return
+	}
+	before := auditSnapshot(user)
+
+	if req.Username != "" {
+		existing, _ := h.userRepo.FindByUsername(req.Username)
+		if existing != nil && existing.ID != 0 && existing.ID != user.ID {
+			apierror.Respond(c, apierror.BadRequest("username_taken"))
+			return
+		}
+		user.Username = req.Username
+	}
+
+	if req.Name != "" {
+		user.Name = req.Name
+	}
+
+	emailChanged := false
+	if req.Email != "" && req.Email != user.Email {
+		existing, _ := h.userRepo.FindByEmail(req.Email)
+		if existing != nil && existing.ID != 0 && existing.ID != user.ID {
+			apierror.Respond(c, apierror.BadRequest("email_taken"))
+			return
+		}
+		user.Email = req.Email
+		user.EmailVerifiedAt = nil
+		emailChanged = true
+	}
+
+	if req.Password != "" {
+		if len(req.Password) < 6 {
+			apierror.Respond(c, apierror.BadRequest("password_too_short"))
+			return
+		}
+		hashedPassword, err := services.HashPassword(req.Password)
+		if err != nil {
+			apierror.Respond(c, apierror.New(http.StatusInternalServerError, "password_hash_failed").Wrap(err))
+			return
+		}
+		user.SetPassword(hashedPassword)
+		user.MustChangePassword = true
+	}
+
+	if req.UserType != "" && req.UserType != user.UserType {
+		if !req.UserType.IsStaff() {
+			apierror.Respond(c, apierror.BadRequest("staff_type_invalid"))
+			return
+		}
+		user.UserType = req.UserType
+	}
+
+	if err := h.userRepo.UpdateKeepingAdmin(user); err != nil {
+		respondStaffSaveError(c, err)
+		return
+	}
+
+	if emailChanged {
+		sendVerificationEmailAsync(*user)
+	}
+	recordAudit(c, "staff.update", models.AuditTargetUser, user.ID, before, auditSnapshot(user))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User berhasil diupdate",
+		"data":    dto.NewAdminUser(*user),
+	})
+}
+
+// DisableStaffUser suspends an admin/editor account (admin only)
+func (h *UserHandler) DisableStaffUser(c *gin.Context) {
+	h.setStaffStatus(c, models.UserStatusSuspend)
+}
+
+// EnableStaffUser reactivates a suspended admin/editor account (admin only)
+func (h *UserHandler) EnableStaffUser(c *gin.Context) {
+	h.setStaffStatus(c, models.UserStatusActive)
+}
+
+func (h *UserHandler) setStaffStatus(c *gin.Context, status string) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	user, ok := h.findStaff(c)
+	if !ok {
+		return
+	}
+
+	if status != models.UserStatusActive {
+		currentUserID, _ := c.Get("user_id")
+		if user.ID == currentUserID.(uint) {
+			apierror.Respond(c, apierror.BadRequest("cannot_disable_self"))
+			return
+		}
+	}
+
+	before := auditSnapshot(user)
+	user.Status = status
+	if err := h.userRepo.UpdateKeepingAdmin(user); err != nil {
+		respondStaffSaveError(c, err)
+		return
+	}
+	action := "staff.enable"
+	if status != models.UserStatusActive {
+		action = "staff.disable"
+	}
+	recordAudit(c, action, models.AuditTargetUser, user.ID, before, auditSnapshot(user))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Status user berhasil diupdate",
+		"data":    dto.NewAdminUser(*user),
+	})
+}
+
+// DeleteStaffUser soft-deletes an admin/editor account (admin only)
+func (h *UserHandler) DeleteStaffUser(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	user, ok := h.findStaff(c)
+	if !ok {
+		return
+	}
+
+	currentUserID, _ := c.Get("user_id")
+	if user.ID == currentUserID.(uint) {
+		apierror.Respond(c, apierror.BadRequest("cannot_delete_self"))
+		return
+	}
+
+	if err := h.userRepo.DeleteKeepingAdmin(user); err != nil {
+		respondStaffSaveError(c, err)
+		return
+	}
+	recordAudit(c, "staff.delete", models.AuditTargetUser, user.ID, auditSnapshot(user), nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "User berhasil dihapus"})
+}
@@ -0,0 +1,8 @@
+package i18n
+
+// catalogs maps a language to its messages by code. Every code must exist
+// in the Indonesian catalog; other languages fall back to it.
+var catalogs = map[string]map[string]string{
+	Indonesian: indonesian,
+	English:    english,
+}
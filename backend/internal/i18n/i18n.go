@@ -0,0 +1,82 @@
+// Package i18n picks the language of a request and translates API messages
+// from per-language catalogs. Indonesian is the default language.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Languages with a message catalog
+const (
+	Indonesian = "id"
+	English    = "en"
+
+	Default = Indonesian
+)
+
+// Negotiate picks the catalog language best matching an Accept-Language
+// header, falling back to Default
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		lang    string
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if value, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = value
+			}
+		}
+		if lang := Normalize(tag); lang != "" && quality > 0 {
+			candidates = append(candidates, candidate{lang, quality})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, c := range candidates {
+		if _, ok := catalogs[c.lang]; ok {
+			return c.lang
+		}
+	}
+	return Default
+}
+
+// Normalize reduces a language tag such as "en-US" to its lowercase primary
+// subtag ("en"). It returns an empty string for invalid tags and "*".
+func Normalize(tag string) string {
+	primary, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	primary = strings.ToLower(primary)
+	if len(primary) < 2 || len(primary) > 3 {
+		return ""
+	}
+	for _, r := range primary {
+		if r < 'a' || r > 'z' {
+			return ""
+		}
+	}
+	return primary
+}
+
+// T returns the message for key in lang, formatted with args. Missing
+// translations fall back to Default, then to the key itself.
+func T(lang, key string, args ...interface{}) string {
+	message, ok := catalogs[lang][key]
+	if !ok {
+		message, ok = catalogs[Default][key]
+	}
+	if !ok {
+		message = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
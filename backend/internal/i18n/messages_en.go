@@ -0,0 +1,195 @@
+package i18n
+
+var english = map[string]string{
+	// Generic
+	"internal_error":       "Internal server error",
+	"invalid_request_body": "Invalid request body",
+	"route_not_found":      "Endpoint not found",
+	"too_many_requests":    "Too many requests. Try again in %d seconds",
+	"query_param_invalid":  "Invalid %s parameter",
+	"language_unsupported": "Language %q is not supported",
+
+	// Request fields
+	"field_required":     "This field is required",
+	"field_email":        "Must be a valid email address",
+	"field_min":          "Must be at least %s",
+	"field_max":          "Must be at most %s",
+	"field_min_length":   "Must be at least %s characters",
+	"field_max_length":   "Must be at most %s characters",
+	"field_invalid_type": "Must be of type %s",
+	"field_invalid":      "Invalid value",
+
+	// Pagination
+	"pagination_invalid_cursor": "Invalid cursor parameter",
+	"pagination_invalid_fields": "Invalid fields parameter: %s",
+
+	// Bulk operations
+	"order_ids_duplicate":     "Each id may appear only once in the order",
+	"order_ids_unknown":       "The order contains ids that do not exist",
+	"order_ids_mixed_parents": "The order may only contain categories with the same parent",
+
+	// Authentication
+	"authorization_required":          "The Authorization header is required",
+	"authorization_invalid":           "Invalid Authorization header format",
+	"token_invalid":                   "Invalid token",
+	"token_generation_failed":         "Failed to generate a token",
+	"invalid_credentials":             "Wrong username or password",
+	"account_disabled":                "Your account has been disabled",
+	"login_session_expired":           "Your login session has expired, please log in again",
+	"password_change_required":        "You must change your password first",
+	"password_incorrect":              "Wrong password",
+	"password_too_short":              "Password must be at least 6 characters",
+	"password_hash_failed":            "Failed to hash the password",
+	"verification_token_invalid":      "The verification token is invalid or has expired",
+	"verification_email_failed":       "Failed to send the verification email",
+	"email_verification_staff_only":   "Email verification is only for admin/editor accounts",
+	"email_already_verified":          "Email is already verified",
+	"reset_token_invalid":             "The password reset token is invalid or has expired",
+	"admin_required":                  "Admin access required",
+	"staff_required":                  "Admin or editor access required",
+	"xinxun_unavailable":              "Failed to reach xinxun.us",
+	"xinxun_login_failed":             "Login failed: %s",
+	"phone_number_invalid":            "Phone number must start with 8 and have 10-13 digits",
+	"phone_number_not_numeric":        "Phone number may only contain digits",
+	"two_factor_setup_required":       "You must enable two-factor authentication first",
+	"two_factor_code_invalid":         "Invalid 2FA code",
+	"two_factor_code_required":        "A 2FA code or recovery code is required",
+	"two_factor_already_enabled":      "2FA is already enabled",
+	"two_factor_not_enabled":          "2FA is not enabled",
+	"two_factor_setup_missing":        "Run the 2FA setup first",
+	"two_factor_staff_only":           "2FA is only available for admin/editor accounts",
+	"two_factor_required_by_policy":   "2FA is required for all admins and cannot be disabled",
+	"two_factor_secret_failed":        "Failed to create the 2FA secret",
+	"recovery_code_generation_failed": "Failed to create recovery codes",
+
+	// Users
+	"user_not_found":          "User not found",
+	"user_not_publisher":      "User is not a publisher",
+	"user_save_failed":        "Failed to save the user",
+	"username_taken":          "Username already exists",
+	"email_taken":             "Email already exists",
+	"staff_type_invalid":      "User type must be admin or editor",
+	"cannot_delete_self":      "You cannot delete your own account",
+	"cannot_disable_self":     "You cannot disable your own account",
+	"last_active_admin":       "Cannot change the last active admin",
+	"bio_too_long":            "Bio cannot be longer than 1000 characters",
+	"avatar_url_invalid":      "Invalid avatar URL",
+	"social_link_invalid":     "Invalid social link URL: %s",
+	"social_link_unsupported": "Unsupported social link: %s",
+	"author_not_found":        "Author not found",
+	"publisher_not_found":     "Publisher not found",
+
+	// News
+	"news_not_found":                  "Article not found",
+	"original_news_not_found":         "Original article not found",
+	"news_search_query_required":      "Query parameter 'q' is required",
+	"news_view_forbidden":             "You can only view your own articles",
+	"news_edit_forbidden":             "You can only edit your own articles",
+	"news_status_publisher_forbidden": "Publishers cannot change the status of an article",
+	"news_not_pending":                "Article is not pending",
+	"news_status_change_invalid":      "An article with status %s cannot be changed to %s",
+	"news_not_in_trash":               "Article not found in the trash",
+	"news_bulk_staff_only":            "Only admins and editors can run bulk actions",
+	"bulk_action_invalid":             "Unknown bulk action %q",
+	"news_not_from_publisher":         "Only publisher articles can be approved",
+	"news_approve_admin_only":         "Only admins can approve articles",
+	"news_reject_admin_only":          "Only admins can reject articles",
+	"pending_limit_reached": "You already have %d articles waiting for review (limit %d). " +
+		"Wait until an admin reviews them before submitting new ones.",
+	"daily_submission_limit_reached": "The limit of %d article submissions per day has been reached. Try again tomorrow.",
+
+	// Article content
+	"content_required":                "Content cannot be empty",
+	"content_too_many_blocks":         "Content cannot have more than %d blocks",
+	"content_blocks_invalid":          "Invalid content blocks",
+	"block_text_required":             "Text cannot be empty",
+	"block_heading_level_invalid":     "Heading level must be between 2 and 6",
+	"block_image_not_allowed":         "Images must come from an allowed storage",
+	"block_embed_not_allowed":         "Embeds must come from an allowed provider",
+	"block_gallery_empty":             "A gallery must contain at least 1 image",
+	"block_gallery_too_many_images":   "A gallery cannot have more than %d images",
+	"block_gallery_image_not_allowed": "Image %d must come from an allowed storage",
+	"block_list_empty":                "A list must contain at least 1 item",
+	"block_list_too_many_items":       "A list cannot have more than %d items",
+	"block_code_empty":                "Code cannot be empty",
+	"block_type_unknown":              "Unknown block type",
+
+	// Content rules
+	"content_rules_violated":                "The article does not meet the content rules",
+	"content_rule_title_max_words":          "Title cannot be longer than %d words",
+	"content_rule_title_min_chars":          "Title must be at least %d characters",
+	"content_rule_title_max_chars":          "Title cannot be longer than %d characters",
+	"content_rule_excerpt_max_words":        "Excerpt cannot be longer than %d words",
+	"content_rule_content_min_words":        "Content must be at least %d words",
+	"content_rule_max_links":                "Content cannot contain more than %d links",
+	"content_rule_min_tags":                 "The article must have at least %d tags",
+	"content_rule_banned_words":             "Contains banned words: %s",
+	"content_rule_thumbnail_unreachable":    "The thumbnail cannot be accessed",
+	"content_rule_thumbnail_dimensions":     "Thumbnail must be at least %dx%d pixels (currently %dx%d)",
+	"content_rule_thumbnail_untrusted_host": "The thumbnail must be uploaded to our storage so its size can be checked",
+	"content_rule_thumbnail_format":         "The thumbnail must be a JPEG, PNG or GIF image so its size can be checked",
+
+	// Translations
+	"translation_source_not_found": "Translation source article not found",
+	"translation_source_forbidden": "You can only translate your own articles",
+	"translation_source_revision":  "A revision cannot be a translation source",
+	"translation_same_language":    "A translation must be in a different language than its source",
+	"translation_language_taken":   "This article already has a translation in %s",
+	"translation_self":             "An article cannot be a translation of itself",
+
+	// Categories and tags
+	"category_not_found":         "Category not found",
+	"category_admin_only":        "This category is for admins only. Only admins can publish articles in this category.",
+	"category_in_use":            "Cannot delete a category that still has articles",
+	"category_has_children":      "Cannot delete a category that still has subcategories",
+	"category_parent_not_found":  "Parent category not found",
+	"category_parent_cycle":      "A category cannot be moved under itself or one of its subcategories",
+	"category_names_unsupported": "Category names for language %q are not supported",
+	"tag_not_found":              "Tag not found",
+	"tag_in_use":                 "Cannot delete a tag that still has articles",
+	"tag_merge_into_self":        "A tag cannot be merged into itself",
+	"tag_name_invalid":           "Tag names must contain letters or numbers and be at most %d characters",
+	"tag_create_forbidden":       "You are not allowed to create new tags",
+	"news_tags_invalid":          "Some tags are invalid",
+	"tag_names_unsupported":      "Tag names for language %q are not supported",
+
+	// Curation and collections
+	"news_revision_not_allowed":      "This is not available for revisions; use the original article",
+	"curation_invalid":               "Invalid curation settings",
+	"curation_until_past":            "The end time must be in the future",
+	"collection_not_found":           "Collection not found",
+	"collection_slug_invalid":        "The collection slug must contain letters or numbers",
+	"collection_slug_exists":         "A collection with slug %q already exists",
+	"collection_items_invalid":       "Some collection items are invalid",
+	"collection_item_duplicate":      "The article is already in the collection",
+	"collection_item_window_invalid": "The expiry time must be after the start time",
+
+	// Live blogs
+	"news_not_live_blog":    "This article is not a live blog",
+	"live_update_not_found": "Live update not found",
+
+	// Rewards and balance
+	"reward_negative":             "Reward cannot be negative",
+	"reward_send_failed":          "The article was approved but the reward could not be sent",
+	"reward_cap_negative":         "Reward caps cannot be negative",
+	"reward_policy_not_found":     "Reward policy not found",
+	"reward_policy_exists":        "A reward policy for this category already exists",
+	"word_count_tier_invalid":     "Invalid word count tier",
+	"bonus_negative":              "Bonus cannot be negative",
+	"milestone_not_found":         "Milestone not found",
+	"milestone_exists":            "A milestone for this view count already exists",
+	"balance_negative":            "Balance cannot be negative",
+	"adjustment_reason_required":  "An adjustment reason is required",
+	"earnings_publisher_only":     "Only publishers have earnings",
+	"publisher_missing_xinxun_id": "Publisher has no xinxun_id",
+
+	// Settings
+	"setting_negative": "Publisher limits and content rules cannot be negative",
+
+	// Uploads
+	"image_required":     "No image file was provided",
+	"file_too_large":     "File too large. Maximum 10MB",
+	"upload_read_failed": "Failed to read the file",
+	"upload_failed":      "Failed to upload the file",
+	"upload_timeout":     "Upload timed out. Please try again with a smaller file.",
+}
@@ -0,0 +1,195 @@
+package i18n
+
+var indonesian = map[string]string{
+	// Generic
+	"internal_error":       "Terjadi kesalahan pada server",
+	"invalid_request_body": "Data request tidak valid",
+	"route_not_found":      "Endpoint tidak ditemukan",
+	"too_many_requests":    "Terlalu banyak permintaan. Coba lagi dalam %d detik",
+	"query_param_invalid":  "Parameter %s tidak valid",
+	"language_unsupported": "Bahasa %q tidak didukung",
+
+	// Request fields
+	"field_required":     "Wajib diisi",
+	"field_email":        "Harus berupa alamat email yang valid",
+	"field_min":          "Minimal %s",
+	"field_max":          "Maksimal %s",
+	"field_min_length":   "Minimal %s karakter",
+	"field_max_length":   "Maksimal %s karakter",
+	"field_invalid_type": "Harus bertipe %s",
+	"field_invalid":      "Nilai tidak valid",
+
+	// Pagination
+	"pagination_invalid_cursor": "Parameter cursor tidak valid",
+	"pagination_invalid_fields": "Parameter fields tidak valid: %s",
+
+	// Bulk operations
+	"order_ids_duplicate":     "Setiap id hanya boleh muncul sekali dalam urutan",
+	"order_ids_unknown":       "Urutan berisi id yang tidak ada",
+	"order_ids_mixed_parents": "Urutan hanya boleh berisi kategori dengan induk yang sama",
+
+	// Authentication
+	"authorization_required":          "Header Authorization wajib diisi",
+	"authorization_invalid":           "Format header Authorization tidak valid",
+	"token_invalid":                   "Token tidak valid",
+	"token_generation_failed":         "Gagal menghasilkan token",
+	"invalid_credentials":             "Username atau password salah",
+	"account_disabled":                "Akun Anda dinonaktifkan",
+	"login_session_expired":           "Sesi login sudah kedaluwarsa, silakan login kembali",
+	"password_change_required":        "Anda wajib mengganti password terlebih dahulu",
+	"password_incorrect":              "Password salah",
+	"password_too_short":              "Password harus minimal 6 karakter",
+	"password_hash_failed":            "Gagal menghash password",
+	"verification_token_invalid":      "Token verifikasi tidak valid atau sudah kedaluwarsa",
+	"verification_email_failed":       "Gagal mengirim email verifikasi",
+	"email_verification_staff_only":   "Verifikasi email hanya untuk akun admin/editor",
+	"email_already_verified":          "Email sudah diverifikasi",
+	"reset_token_invalid":             "Token reset password tidak valid atau sudah kedaluwarsa",
+	"admin_required":                  "Akses admin diperlukan",
+	"staff_required":                  "Akses admin atau editor diperlukan",
+	"xinxun_unavailable":              "Gagal menghubungi xinxun.us",
+	"xinxun_login_failed":             "Login gagal: %s",
+	"phone_number_invalid":            "Nomor telepon harus dimulai dengan 8 dan memiliki 10-13 digit",
+	"phone_number_not_numeric":        "Nomor telepon hanya boleh berisi angka",
+	"two_factor_setup_required":       "Anda wajib mengaktifkan autentikasi dua faktor terlebih dahulu",
+	"two_factor_code_invalid":         "Kode 2FA tidak valid",
+	"two_factor_code_required":        "Kode 2FA atau recovery code wajib diisi",
+	"two_factor_already_enabled":      "2FA sudah aktif",
+	"two_factor_not_enabled":          "2FA belum aktif",
+	"two_factor_setup_missing":        "Jalankan setup 2FA terlebih dahulu",
+	"two_factor_staff_only":           "2FA hanya tersedia untuk akun admin/editor",
+	"two_factor_required_by_policy":   "2FA diwajibkan untuk semua admin dan tidak dapat dinonaktifkan",
+	"two_factor_secret_failed":        "Gagal membuat secret 2FA",
+	"recovery_code_generation_failed": "Gagal membuat recovery code",
+
+	// Users
+	"user_not_found":          "User tidak ditemukan",
+	"user_not_publisher":      "User tidak adalah publisher",
+	"user_save_failed":        "Gagal menyimpan pengguna",
+	"username_taken":          "Username sudah ada",
+	"email_taken":             "Email sudah ada",
+	"staff_type_invalid":      "Tipe user harus admin atau editor",
+	"cannot_delete_self":      "Tidak dapat menghapus akun sendiri",
+	"cannot_disable_self":     "Tidak dapat menonaktifkan akun sendiri",
+	"last_active_admin":       "Tidak dapat mengubah admin aktif terakhir",
+	"bio_too_long":            "Bio tidak boleh lebih dari 1000 karakter",
+	"avatar_url_invalid":      "URL avatar tidak valid",
+	"social_link_invalid":     "URL social link tidak valid: %s",
+	"social_link_unsupported": "Social link tidak didukung: %s",
+	"author_not_found":        "Author tidak ditemukan",
+	"publisher_not_found":     "Publisher tidak ditemukan",
+
+	// News
+	"news_not_found":                  "Artikel tidak ditemukan",
+	"original_news_not_found":         "Artikel asli tidak ditemukan",
+	"news_search_query_required":      "Parameter query 'q' wajib diisi",
+	"news_view_forbidden":             "Anda hanya dapat melihat artikel milik Anda sendiri",
+	"news_edit_forbidden":             "Anda hanya dapat mengedit artikel milik Anda sendiri",
+	"news_status_publisher_forbidden": "Publisher tidak dapat mengubah status artikel",
+	"news_not_pending":                "Artikel tidak berstatus pending",
+	"news_status_change_invalid":      "Artikel berstatus %s tidak dapat diubah menjadi %s",
+	"news_not_in_trash":               "Artikel tidak ditemukan di tempat sampah",
+	"news_bulk_staff_only":            "Hanya admin dan editor yang dapat menjalankan aksi massal",
+	"bulk_action_invalid":             "Aksi massal %q tidak dikenal",
+	"news_not_from_publisher":         "Hanya artikel publisher yang dapat diapprove",
+	"news_approve_admin_only":         "Hanya admin yang dapat approve artikel",
+	"news_reject_admin_only":          "Hanya admin yang dapat reject artikel",
+	"pending_limit_reached": "Anda sudah memiliki %d artikel yang menunggu review (batas %d). " +
+		"Tunggu hingga admin meninjau artikel Anda sebelum mengirim yang baru.",
+	"daily_submission_limit_reached": "Batas %d pengiriman artikel per hari sudah tercapai. Coba lagi besok.",
+
+	// Article content
+	"content_required":                "Konten tidak boleh kosong",
+	"content_too_many_blocks":         "Konten tidak boleh lebih dari %d blok",
+	"content_blocks_invalid":          "Blok konten tidak valid",
+	"block_text_required":             "Teks tidak boleh kosong",
+	"block_heading_level_invalid":     "Level heading harus antara 2 dan 6",
+	"block_image_not_allowed":         "Gambar harus berasal dari storage yang diizinkan",
+	"block_embed_not_allowed":         "Embed harus berasal dari penyedia yang diizinkan",
+	"block_gallery_empty":             "Galeri harus berisi minimal 1 gambar",
+	"block_gallery_too_many_images":   "Galeri tidak boleh lebih dari %d gambar",
+	"block_gallery_image_not_allowed": "Gambar %d harus berasal dari storage yang diizinkan",
+	"block_list_empty":                "List harus berisi minimal 1 item",
+	"block_list_too_many_items":       "List tidak boleh lebih dari %d item",
+	"block_code_empty":                "Kode tidak boleh kosong",
+	"block_type_unknown":              "Tipe blok tidak dikenal",
+
+	// Content rules
+	"content_rules_violated":                "Artikel tidak memenuhi aturan konten",
+	"content_rule_title_max_words":          "Judul tidak boleh lebih dari %d kata",
+	"content_rule_title_min_chars":          "Judul minimal %d karakter",
+	"content_rule_title_max_chars":          "Judul tidak boleh lebih dari %d karakter",
+	"content_rule_excerpt_max_words":        "Excerpt tidak boleh lebih dari %d kata",
+	"content_rule_content_min_words":        "Konten minimal %d kata",
+	"content_rule_max_links":                "Konten tidak boleh berisi lebih dari %d link",
+	"content_rule_min_tags":                 "Artikel harus memiliki minimal %d tag",
+	"content_rule_banned_words":             "Mengandung kata terlarang: %s",
+	"content_rule_thumbnail_unreachable":    "Thumbnail tidak dapat diakses",
+	"content_rule_thumbnail_dimensions":     "Thumbnail minimal %dx%d piksel (saat ini %dx%d)",
+	"content_rule_thumbnail_untrusted_host": "Thumbnail harus diunggah ke penyimpanan kami agar ukurannya dapat diperiksa",
+	"content_rule_thumbnail_format":         "Thumbnail harus berupa gambar JPEG, PNG atau GIF agar ukurannya dapat diperiksa",
+
+	// Translations
+	"translation_source_not_found": "Artikel sumber terjemahan tidak ditemukan",
+	"translation_source_forbidden": "Anda hanya dapat menerjemahkan artikel milik Anda sendiri",
+	"translation_source_revision":  "Revisi tidak dapat menjadi sumber terjemahan",
+	"translation_same_language":    "Terjemahan harus berbeda bahasa dengan artikel sumber",
+	"translation_language_taken":   "Artikel ini sudah memiliki terjemahan dalam bahasa %s",
+	"translation_self":             "Artikel tidak dapat menjadi terjemahan dirinya sendiri",
+
+	// Categories and tags
+	"category_not_found":         "Kategori tidak ditemukan",
+	"category_admin_only":        "Kategori ini hanya untuk admin. Hanya admin yang dapat memublikasikan artikel dengan kategori ini.",
+	"category_in_use":            "Tidak dapat menghapus kategori dengan artikel yang ada",
+	"category_has_children":      "Tidak dapat menghapus kategori yang masih memiliki subkategori",
+	"category_parent_not_found":  "Kategori induk tidak ditemukan",
+	"category_parent_cycle":      "Kategori tidak dapat dipindahkan ke dalam dirinya sendiri atau subkategorinya",
+	"category_names_unsupported": "Nama kategori untuk bahasa %q tidak didukung",
+	"tag_not_found":              "Tag tidak ditemukan",
+	"tag_in_use":                 "Tidak dapat menghapus tag dengan artikel yang ada",
+	"tag_merge_into_self":        "Tag tidak dapat digabung ke dirinya sendiri",
+	"tag_name_invalid":           "Nama tag harus berisi huruf atau angka dan maksimal %d karakter",
+	"tag_create_forbidden":       "Anda tidak diizinkan membuat tag baru",
+	"news_tags_invalid":          "Beberapa tag tidak valid",
+	"tag_names_unsupported":      "Nama tag untuk bahasa %q tidak didukung",
+
+	// Curation and collections
+	"news_revision_not_allowed":      "Tidak tersedia untuk revisi; gunakan artikel aslinya",
+	"curation_invalid":               "Pengaturan kurasi tidak valid",
+	"curation_until_past":            "Waktu berakhir harus di masa depan",
+	"collection_not_found":           "Koleksi tidak ditemukan",
+	"collection_slug_invalid":        "Slug koleksi harus mengandung huruf atau angka",
+	"collection_slug_exists":         "Koleksi dengan slug %q sudah ada",
+	"collection_items_invalid":       "Beberapa item koleksi tidak valid",
+	"collection_item_duplicate":      "Artikel sudah ada di koleksi",
+	"collection_item_window_invalid": "Waktu berakhir harus setelah waktu mulai",
+
+	// Live blog
+	"news_not_live_blog":    "Artikel ini bukan live blog",
+	"live_update_not_found": "Live update tidak ditemukan",
+
+	// Rewards and balance
+	"reward_negative":             "Reward tidak boleh negatif",
+	"reward_send_failed":          "Artikel berhasil diapprove tetapi reward gagal dikirim",
+	"reward_cap_negative":         "Batas reward tidak boleh negatif",
+	"reward_policy_not_found":     "Kebijakan reward tidak ditemukan",
+	"reward_policy_exists":        "Kebijakan reward untuk kategori ini sudah ada",
+	"word_count_tier_invalid":     "Tier jumlah kata tidak valid",
+	"bonus_negative":              "Bonus tidak boleh negatif",
+	"milestone_not_found":         "Milestone tidak ditemukan",
+	"milestone_exists":            "Milestone untuk jumlah views ini sudah ada",
+	"balance_negative":            "Saldo tidak boleh negatif",
+	"adjustment_reason_required":  "Alasan penyesuaian wajib diisi",
+	"earnings_publisher_only":     "Hanya publisher yang memiliki pendapatan",
+	"publisher_missing_xinxun_id": "Publisher tidak memiliki xinxun_id",
+
+	// Settings
+	"setting_negative": "Batas publisher dan aturan konten tidak boleh negatif",
+
+	// Uploads
+	"image_required":     "Tidak ada file gambar yang diberikan",
+	"file_too_large":     "File terlalu besar. Maksimal 10MB",
+	"upload_read_failed": "Gagal membaca file",
+	"upload_failed":      "Gagal mengupload file",
+	"upload_timeout":     "Upload timeout. Silakan coba lagi dengan file yang lebih kecil.",
+}
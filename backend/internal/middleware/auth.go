@@ -1,10 +1,11 @@
 package middleware
 
 import (
-	"net/http"
 	"strings"
 
+	"xinxun-news/internal/apierror"
 	"xinxun-news/internal/config"
+	"xinxun-news/internal/repository"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -14,15 +15,13 @@ func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Header Authorization wajib diisi"})
-			c.Abort()
+			apierror.Respond(c, apierror.Unauthorized("authorization_required"))
 			return
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenString == authHeader {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Format header Authorization tidak valid"})
-			c.Abort()
+			apierror.Respond(c, apierror.Unauthorized("authorization_invalid"))
 			return
 		}
 
@@ -34,35 +33,77 @@ func AuthMiddleware() gin.HandlerFunc {
 		})
 
 		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token tidak valid"})
-			c.Abort()
+			apierror.Respond(c, apierror.Unauthorized("token_invalid"))
 			return
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token claims tidak valid"})
-			c.Abort()
+			apierror.Respond(c, apierror.Unauthorized("token_invalid"))
+			return
+		}
+
+		// Challenge tokens from the 2FA login step are not session tokens
+		if _, isChallenge := claims["purpose"]; isChallenge {
+			apierror.Respond(c, apierror.Unauthorized("token_invalid"))
 			return
 		}
 
 		// Safe type assertion with validation
 		userIDFloat, ok := claims["user_id"].(float64)
 		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token claims user_id tidak valid"})
-			c.Abort()
+			apierror.Respond(c, apierror.Unauthorized("token_invalid"))
 			return
 		}
 
-		userType, ok := claims["user_type"].(string)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token claims user_type tidak valid"})
-			c.Abort()
+		if _, ok := claims["user_type"].(string); !ok {
+			apierror.Respond(c, apierror.Unauthorized("token_invalid"))
+			return
+		}
+
+		// Role and status come from the database, not the claims, so a
+		// suspended, deleted or demoted account loses access immediately
+		// instead of when its token expires.
+		user, err := repository.NewUserRepository().FindByID(uint(userIDFloat))
+		if err != nil {
+			apierror.Respond(c, apierror.Unauthorized("token_invalid"))
+			return
+		}
+		if !user.IsActive() {
+			apierror.Respond(c, apierror.Forbidden("account_disabled"))
+			return
+		}
+
+		// Tokens issued before the last password change are revoked. Tokens
+		// without the claim predate versioning and count as version 0.
+		tokenVersion, _ := claims["token_version"].(float64)
+		if uint(tokenVersion) != user.TokenVersion {
+			apierror.Respond(c, apierror.Unauthorized("token_invalid"))
 			return
 		}
 
-		c.Set("user_id", uint(userIDFloat))
-		c.Set("user_type", userType)
+		mustSetupTwoFactor, _ := claims["must_setup_two_factor"].(bool)
+
+		c.Set("user_id", user.ID)
+		c.Set("user_type", string(user.UserType))
+		c.Set("must_change_password", user.MustChangePassword)
+		c.Set("must_setup_two_factor", mustSetupTwoFactor)
+		c.Next()
+	}
+}
+
+// RequireAccountSetup blocks accounts that still have to replace their
+// initial password or enroll in two-factor auth. Must run after AuthMiddleware.
+func RequireAccountSetup() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool("must_change_password") {
+			apierror.Respond(c, apierror.Forbidden("password_change_required"))
+			return
+		}
+		if c.GetBool("must_setup_two_factor") {
+			apierror.Respond(c, apierror.Forbidden("two_factor_setup_required"))
+			return
+		}
 		c.Next()
 	}
 }
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/config"
+	"xinxun-news/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit limits requests per client IP and, when the request is
+// authenticated, per account. name separates the buckets of different
+// route classes (read, login, upload, write).
+func RateLimit(name string, rate ratelimit.Rate) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !config.AppConfig.RateLimitEnabled {
+			c.Next()
+			return
+		}
+
+		keys := []string{name + ":ip:" + c.ClientIP()}
+		if userID, exists := c.Get("user_id"); exists {
+			keys = append(keys, fmt.Sprintf("%s:user:%v", name, userID))
+		}
+
+		for _, key := range keys {
+			allowed, retryAfter, err := ratelimit.Allow(key, rate)
+			if err != nil {
+				// Fail open, an unavailable store must not take the API down
+				log.Printf("[RateLimit] store error for %s: %v", key, err)
+				continue
+			}
+			if !allowed {
+				TooManyRequests(c, retryAfter)
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// WriteRateLimit applies RateLimit to state-changing methods only
+func WriteRateLimit(rate ratelimit.Rate) gin.HandlerFunc {
+	limiter := RateLimit("write", rate)
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+		default:
+			limiter(c)
+		}
+	}
+}
+
+// TooManyRequests aborts with 429 and a Retry-After header in whole seconds
+func TooManyRequests(c *gin.Context, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	apierror.Respond(c, apierror.New(http.StatusTooManyRequests, "too_many_requests", seconds).
+		With("retry_after", seconds))
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Audit target types
+const (
+	AuditTargetNews     = "news"
+	AuditTargetUser     = "user"
+	AuditTargetCategory = "category"
+	AuditTargetTag      = "tag"
+	AuditTargetSetting  = "setting"
+
+	AuditTargetRewardPolicy    = "reward_policy"
+	AuditTargetRewardMilestone = "reward_milestone"
+	AuditTargetCollection      = "collection"
+	AuditTargetLiveUpdate      = "live_update"
+)
+
+// AuditLog records one administrative action: who did what to which record
+type AuditLog struct {
+	ID         uint         `json:"id" gorm:"primaryKey"`
+	ActorID    *uint        `json:"actor_id" gorm:"index"`
+	ActorType  string       `json:"actor_type" gorm:"type:varchar(20)"`
+	Actor      *User        `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+	Action     string       `json:"action" gorm:"type:varchar(64);index;not null"` // e.g. news.approve, publisher.update
+	TargetType string       `json:"target_type" gorm:"type:varchar(32);index:idx_audit_target"`
+	TargetID   uint         `json:"target_id" gorm:"index:idx_audit_target"`
+	Changes    AuditChanges `json:"changes" gorm:"type:text"`
+	IPAddress  string       `json:"ip_address" gorm:"type:varchar(64)"`
+	UserAgent  string       `json:"user_agent" gorm:"type:varchar(500)"`
+	CreatedAt  time.Time    `json:"created_at" gorm:"index"`
+}
+
+// AuditChange holds the value of a field before and after an action.
+// Before is nil for created records and After is nil for deleted ones.
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditChanges maps a field name to its change. Stored as a JSON string column.
+type AuditChanges map[string]AuditChange
+
+func (a AuditChanges) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return "", nil
+	}
+	raw, err := json.Marshal(a)
+	return string(raw), err
+}
+
+func (a *AuditChanges) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return errors.New("unsupported type for AuditChanges")
+	}
+	if len(raw) == 0 {
+		*a = nil
+		return nil
+	}
+	return json.Unmarshal(raw, a)
+}
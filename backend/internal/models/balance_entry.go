@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type BalanceEntryType string
+
+const (
+	BalanceEntryReward         BalanceEntryType = "reward"          // Reward artikel yang di-approve
+	BalanceEntryMilestone      BalanceEntryType = "milestone"       // Bonus saat artikel mencapai jumlah views tertentu
+	BalanceEntryAdjustment     BalanceEntryType = "adjustment"      // Penyesuaian manual oleh admin
+	BalanceEntrySyncCorrection BalanceEntryType = "sync_correction" // Selisih saldo saat sinkronisasi dengan xinxun.us
+	BalanceEntryOpening        BalanceEntryType = "opening"         // Saldo users.balance saat ledger mulai dipakai
+)
+
+// BalanceEntry is one movement in a publisher's balance ledger. Entries are
+// never updated; User.Balance is the running sum kept in BalanceAfter.
+type BalanceEntry struct {
+	ID           uint             `json:"id" gorm:"primaryKey"`
+	UserID       uint             `json:"user_id" gorm:"index;not null"`
+	Type         BalanceEntryType `json:"type" gorm:"type:varchar(20);index;not null"`
+	Amount       Money            `json:"amount" gorm:"type:decimal(15,2);not null"`
+	BalanceAfter Money            `json:"balance_after" gorm:"type:decimal(15,2);not null"`
+	NewsID       *uint            `json:"news_id" gorm:"index"`
+	News         *News            `json:"news,omitempty" gorm:"foreignKey:NewsID"`
+	Reason       string           `json:"reason" gorm:"type:varchar(500)"`
+	Rule         string           `json:"rule" gorm:"type:text"` // RewardSuggestion (JSON) yang diterapkan untuk reward
+	Milestone    int              `json:"milestone_views" gorm:"default:0"`
+	ActorID      *uint            `json:"actor_id"` // Admin yang mencatat, kosong untuk sinkronisasi otomatis
+	CreatedAt    time.Time        `json:"created_at" gorm:"index"`
+}
@@ -9,7 +9,9 @@ import (
 type Category struct {
 	ID          uint           `json:"id" gorm:"primaryKey"`
 	Name        string         `json:"name" gorm:"not null"`
+	Names       LocalizedNames `json:"names" gorm:"type:text"` // Nama dalam bahasa lain
 	Slug        string         `json:"slug" gorm:"unique;not null"`
+	ParentID    *uint          `json:"parent_id" gorm:"index"` // Kategori induk, nil untuk kategori utama
 	IsAdminOnly bool           `json:"is_admin_only" gorm:"default:false"`
 	Order       int            `json:"order" gorm:"default:0"` // Urutan tampilan
 	CreatedAt   time.Time      `json:"created_at"`
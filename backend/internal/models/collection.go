@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Collection is a named list of articles curated by editors, such as
+// "homepage-hero" or "editor-picks"
+type Collection struct {
+	ID          uint             `json:"id" gorm:"primaryKey"`
+	Name        string           `json:"name" gorm:"not null"`
+	Slug        string           `json:"slug" gorm:"type:varchar(255);uniqueIndex;not null"`
+	Description string           `json:"description" gorm:"type:text"`
+	Items       []CollectionItem `json:"items,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// CollectionItem places one article in a collection. Items are shown by
+// Position and only between StartsAt and ExpiresAt when those are set.
+type CollectionItem struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	CollectionID uint       `json:"collection_id" gorm:"uniqueIndex:idx_collection_news;not null"`
+	NewsID       uint       `json:"news_id" gorm:"uniqueIndex:idx_collection_news;not null"`
+	News         *News      `json:"news,omitempty" gorm:"foreignKey:NewsID"`
+	Position     int        `json:"position" gorm:"default:0"`
+	StartsAt     *time.Time `json:"starts_at"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// Active reports whether the item is shown at a point in time
+func (i CollectionItem) Active(at time.Time) bool {
+	return (i.StartsAt == nil || !i.StartsAt.After(at)) &&
+		(i.ExpiresAt == nil || i.ExpiresAt.After(at))
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// Content block types
+const (
+	BlockTypeParagraph = "paragraph"
+	BlockTypeHeading   = "heading"
+	BlockTypeImage     = "image"
+	BlockTypeQuote     = "quote"
+	BlockTypeEmbed     = "embed"
+	BlockTypeGallery   = "gallery"
+	BlockTypeList      = "list"
+	BlockTypeCode      = "code"
+)
+
+// ContentBlock is one block of a structured article. Which fields are used
+// depends on Type:
+//   - paragraph, heading, quote: Text (inline HTML), Level (heading, 2-6),
+//     Caption (quote attribution)
+//   - image, embed: URL, Alt (image), Caption
+//   - gallery: Images, Caption
+//   - list: Items (inline HTML), Ordered
+//   - code: Text (plain text), Language
+type ContentBlock struct {
+	Type     string         `json:"type"`
+	Text     string         `json:"text,omitempty"`
+	Level    int            `json:"level,omitempty"`
+	URL      string         `json:"url,omitempty"`
+	Alt      string         `json:"alt,omitempty"`
+	Caption  string         `json:"caption,omitempty"`
+	Items    []string       `json:"items,omitempty"`
+	Ordered  bool           `json:"ordered,omitempty"`
+	Images   []GalleryImage `json:"images,omitempty"`
+	Language string         `json:"language,omitempty"`
+}
+
+// GalleryImage is one image of a gallery block
+type GalleryImage struct {
+	URL     string `json:"url"`
+	Alt     string `json:"alt,omitempty"`
+	Caption string `json:"caption,omitempty"`
+}
+
+// ContentBlocks is the block list of a structured article. Stored as a JSON
+// string column; empty for articles written as raw HTML.
+type ContentBlocks []ContentBlock
+
+func (b ContentBlocks) Value() (driver.Value, error) {
+	if len(b) == 0 {
+		return "", nil
+	}
+	raw, err := json.Marshal(b)
+	return string(raw), err
+}
+
+func (b *ContentBlocks) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		*b = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return errors.New("unsupported type for ContentBlocks")
+	}
+	if len(raw) == 0 {
+		*b = nil
+		return nil
+	}
+	return json.Unmarshal(raw, b)
+}
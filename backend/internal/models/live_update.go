@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LiveUpdate is one timestamped entry of a live blog article. Entries are
+// soft-deleted so polling clients can learn about removals.
+type LiveUpdate struct {
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	NewsID        uint           `json:"news_id" gorm:"index;not null"`
+	AuthorID      uint           `json:"author_id"`
+	Author        User           `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+	Content       string         `json:"content" gorm:"type:text;not null"`
+	ContentBlocks ContentBlocks  `json:"content_blocks" gorm:"type:mediumtext"`
+	CreatedAt     time.Time      `json:"created_at" gorm:"index"`
+	UpdatedAt     time.Time      `json:"updated_at" gorm:"index"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+}
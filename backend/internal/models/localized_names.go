@@ -0,0 +1,48 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// LocalizedNames maps a language code (en, zh, ...) to a translated name.
+// The record's own Name is used for languages without an entry. Stored as
+// a JSON string column.
+type LocalizedNames map[string]string
+
+// Get returns the name for lang, or fallback when there is none
+func (n LocalizedNames) Get(lang, fallback string) string {
+	if name := n[lang]; name != "" {
+		return name
+	}
+	return fallback
+}
+
+func (n LocalizedNames) Value() (driver.Value, error) {
+	if len(n) == 0 {
+		return "", nil
+	}
+	raw, err := json.Marshal(n)
+	return string(raw), err
+}
+
+func (n *LocalizedNames) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		*n = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return errors.New("unsupported type for LocalizedNames")
+	}
+	if len(raw) == 0 {
+		*n = nil
+		return nil
+	}
+	return json.Unmarshal(raw, n)
+}
@@ -1,8 +1,12 @@
 package models
 
 import (
+	"strings"
 	"time"
 
+	"xinxun-news/internal/similarity"
+
+	"golang.org/x/net/html"
 	"gorm.io/gorm"
 )
 
@@ -13,15 +17,29 @@ const (
 	StatusPublished NewsStatus = "published"
 	StatusPending   NewsStatus = "pending"   // Menunggu approval admin
 	StatusRejected  NewsStatus = "rejected" // Ditolak admin
+	StatusUnpublished NewsStatus = "unpublished" // Disembunyikan sementara, bisa diterbitkan lagi
+	StatusArchived  NewsStatus = "archived" // Diarsipkan, tidak tampil di publik
+)
+
+type NewsType string
+
+const (
+	TypeStandard NewsType = "standard"
+	TypeLiveBlog NewsType = "live_blog" // Terus diperbarui dengan entri live update
 )
 
 type News struct {
 	ID          uint           `json:"id" gorm:"primaryKey"`
 	Title       string         `json:"title" gorm:"not null"`
 	Slug        string         `json:"slug" gorm:"unique;not null;index"`
+	Language    string         `json:"language" gorm:"type:varchar(10);default:'id';index"` // Bahasa artikel (id, en, zh, ...)
+	TranslationGroupID *uint   `json:"translation_group_id" gorm:"index"` // Artikel yang sama dalam bahasa lain berbagi grup ini
 	Content     string         `json:"content" gorm:"type:text;not null"`
+	ContentBlocks ContentBlocks `json:"content_blocks" gorm:"type:mediumtext"` // Sumber konten berbasis blok; Content berisi hasil render HTML-nya
 	Excerpt     string         `json:"excerpt" gorm:"type:text"`
 	Thumbnail   string         `json:"thumbnail"`
+	AutoExcerpt   bool         `json:"auto_excerpt" gorm:"default:false"` // Excerpt dibuat otomatis dari konten
+	AutoThumbnail bool         `json:"auto_thumbnail" gorm:"default:false"` // Thumbnail diambil dari gambar pertama konten
 	CategoryID  uint           `json:"category_id"`
 	Category    Category       `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
 	AuthorID    uint           `json:"author_id"`
@@ -29,12 +47,168 @@ type News struct {
 	Tags        []Tag          `json:"tags,omitempty" gorm:"many2many:news_tags;"`
 	PublishedAt *time.Time     `json:"published_at"`
 	Views       int            `json:"views" gorm:"default:0"`
-	Status      NewsStatus     `json:"status" gorm:"type:enum('draft','published','pending','rejected');default:'draft'"`
+	IsPinned    bool           `json:"is_pinned" gorm:"default:false;index"` // Ditampilkan paling atas di daftar berita
+	PinnedUntil *time.Time     `json:"pinned_until"` // Pin berakhir otomatis, nil = tanpa batas
+	IsFeatured  bool           `json:"is_featured" gorm:"default:false;index"` // Dipilih editor untuk slider featured
+	FeaturedOrder int          `json:"featured_order" gorm:"default:0"` // Urutan di slider featured
+	FeaturedUntil *time.Time   `json:"featured_until"` // Featured berakhir otomatis, nil = tanpa batas
+	Type        NewsType       `json:"type" gorm:"type:enum('standard','live_blog');default:'standard'"`
+	IsBreaking  bool           `json:"is_breaking" gorm:"default:false;index"` // Ditandai sebagai breaking news
+	BreakingUntil *time.Time   `json:"breaking_until"` // Label breaking berakhir otomatis, nil = tanpa batas
+	WordCount   int            `json:"word_count" gorm:"default:0"` // Jumlah kata konten, dihitung saat disimpan
+	ReadingTime int            `json:"reading_time" gorm:"default:0"` // Perkiraan waktu baca dalam menit
+	Status      NewsStatus     `json:"status" gorm:"type:enum('draft','published','pending','rejected','unpublished','archived');default:'draft'"`
 	RewardAmount float64       `json:"reward_amount" gorm:"default:0"` // Reward untuk publisher jika di-approve
 	IsRewarded  bool           `json:"is_rewarded" gorm:"default:false"` // Apakah sudah diberikan reward
 	RevisionOf  *uint          `json:"revision_of" gorm:"index"` // ID of the original news if this is a revision
+	RejectionReason string     `json:"rejection_reason" gorm:"type:text"` // Alasan penolakan untuk publisher
+	ContentSignature string    `json:"-" gorm:"type:text"` // MinHash signature konten untuk deteksi duplikat
+	SimilarityScore  float64   `json:"similarity_score" gorm:"default:0"` // Kemiripan tertinggi dengan artikel lain saat dikirim (0-1)
+	SimilarMatches   SimilarMatches `json:"similar_matches" gorm:"type:text"` // Artikel yang paling mirip saat dikirim
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
+// PinnedActive reports whether the article is pinned at a point in time
+func (n News) PinnedActive(at time.Time) bool {
+	return n.IsPinned && (n.PinnedUntil == nil || n.PinnedUntil.After(at))
+}
+
+// BreakingActive reports whether the article is breaking news at a point
+// in time
+func (n News) BreakingActive(at time.Time) bool {
+	return n.IsBreaking && (n.BreakingUntil == nil || n.BreakingUntil.After(at))
+}
+
+const (
+	// ReadingWordsPerMinute is the reading speed used for ReadingTime
+	ReadingWordsPerMinute = 200
+	// autoExcerptWords is the length of generated excerpts
+	autoExcerptWords = 40
+)
+
+// BeforeSave keeps the computed metadata in sync with the content: word
+// count, reading time and content signature, plus the excerpt and thumbnail
+// when none was given or they were generated before. Records loaded without
+// their content keep the stored values. Articles without a type are
+// standard articles.
+func (n *News) BeforeSave(tx *gorm.DB) error {
+	if n.Type == "" {
+		n.Type = TypeStandard
+	}
+	if n.Content == "" {
+		return nil
+	}
+
+	text := PlainText(n.Content)
+	words := strings.Fields(text)
+	n.WordCount = len(words)
+	n.ReadingTime = ReadingTime(n.WordCount)
+	n.ContentSignature = similarity.Signature(text)
+
+	if n.Excerpt == "" || n.AutoExcerpt {
+		n.Excerpt = excerptFromWords(words)
+		n.AutoExcerpt = true
+	}
+	if n.Thumbnail == "" || n.AutoThumbnail {
+		n.Thumbnail = FirstImage(n.Content)
+		n.AutoThumbnail = n.Thumbnail != ""
+	}
+	return nil
+}
+
+// AfterSave keeps the similarity bands in step with the content signature
+// computed in BeforeSave. Records saved without their content keep their
+// bands.
+func (n *News) AfterSave(tx *gorm.DB) error {
+	if n.Content == "" {
+		return nil
+	}
+	return ReplaceSignatureBands(tx, n.ID, n.ContentSignature)
+}
+
+// ReadingTime estimates the minutes needed to read wordCount words,
+// rounded up
+func ReadingTime(wordCount int) int {
+	return (wordCount + ReadingWordsPerMinute - 1) / ReadingWordsPerMinute
+}
+
+// excerptFromWords joins the first words of the content into an excerpt
+func excerptFromWords(words []string) string {
+	if len(words) <= autoExcerptWords {
+		return html.UnescapeString(strings.Join(words, " "))
+	}
+	return html.UnescapeString(strings.Join(words[:autoExcerptWords], " ")) + "…"
+}
+
+// FirstImage returns the source of the first image in an HTML fragment, or
+// an empty string
+func FirstImage(content string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return ""
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			if string(name) != "img" {
+				continue
+			}
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				if string(key) == "src" && strings.TrimSpace(string(value)) != "" {
+					return strings.TrimSpace(string(value))
+				}
+			}
+		}
+	}
+}
+
+// Images returns the sources of every image in an HTML fragment, in order
+func Images(content string) []string {
+	var sources []string
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return sources
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			if string(name) != "img" {
+				continue
+			}
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				if string(key) == "src" && strings.TrimSpace(string(value)) != "" {
+					sources = append(sources, strings.TrimSpace(string(value)))
+				}
+			}
+		}
+	}
+}
+
+// CountWords counts the words of an HTML fragment, ignoring tags
+func CountWords(html string) int {
+	return len(strings.Fields(PlainText(html)))
+}
+
+// PlainText strips the tags of an HTML fragment, leaving its text
+func PlainText(html string) string {
+	var text strings.Builder
+	inTag := false
+	for _, r := range html {
+		switch {
+		case r == '<':
+			inTag = true
+			text.WriteRune(' ')
+		case r == '>':
+			inTag = false
+		case !inTag:
+			text.WriteRune(r)
+		}
+	}
+	return strings.ReplaceAll(text.String(), "&nbsp;", " ")
+}
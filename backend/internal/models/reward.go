@@ -0,0 +1,130 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// RewardPolicy defines how the suggested reward of an approved article is
+// computed. The policy with a nil CategoryID is the default for categories
+// without their own policy.
+type RewardPolicy struct {
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	CategoryID     *uint          `json:"category_id" gorm:"uniqueIndex"`
+	Category       *Category      `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	BaseAmount     Money          `json:"base_amount" gorm:"type:decimal(15,2);default:0"`
+	WordCountTiers WordCountTiers `json:"word_count_tiers" gorm:"type:text"`
+	ThumbnailBonus Money          `json:"thumbnail_bonus" gorm:"type:decimal(15,2);default:0"` // Bonus jika artikel memiliki thumbnail
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// WordCountTier pays Bonus to articles with at least MinWords words
+type WordCountTier struct {
+	MinWords int   `json:"min_words"`
+	Bonus    Money `json:"bonus"`
+}
+
+// WordCountTiers is stored as a JSON string column. Only the highest tier
+// an article reaches is paid.
+type WordCountTiers []WordCountTier
+
+func (w WordCountTiers) Value() (driver.Value, error) {
+	if len(w) == 0 {
+		return "", nil
+	}
+	raw, err := json.Marshal(w)
+	return string(raw), err
+}
+
+func (w *WordCountTiers) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		*w = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return errors.New("unsupported type for WordCountTiers")
+	}
+	if len(raw) == 0 {
+		*w = nil
+		return nil
+	}
+	return json.Unmarshal(raw, w)
+}
+
+// Bonus returns the bonus of the highest tier reached by wordCount
+func (w WordCountTiers) Bonus(wordCount int) Money {
+	var bonus Money
+	best := -1
+	for _, tier := range w {
+		if wordCount >= tier.MinWords && tier.MinWords > best {
+			best = tier.MinWords
+			bonus = tier.Bonus
+		}
+	}
+	return bonus
+}
+
+// RewardMilestone pays Bonus once when a published article reaches Views
+type RewardMilestone struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Views     int       `json:"views" gorm:"uniqueIndex;not null"`
+	Bonus     Money     `json:"bonus" gorm:"type:decimal(15,2);not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type MilestonePayoutStatus string
+
+const (
+	MilestonePayoutPending MilestonePayoutStatus = "pending" // Diklaim, pembayaran ke xinxun.us sedang dikirim
+	MilestonePayoutPaid    MilestonePayoutStatus = "paid"    // Dibayar (atau tidak perlu dibayar karena cap)
+)
+
+// MilestonePayout claims the bonus of one milestone for one article before
+// it is sent to xinxun.us. The unique key makes sure a bonus is sent at most
+// once, even when the ledger entry cannot be written afterwards.
+type MilestonePayout struct {
+	ID        uint                  `json:"id" gorm:"primaryKey"`
+	NewsID    uint                  `json:"news_id" gorm:"not null;uniqueIndex:idx_news_milestone"`
+	Milestone int                   `json:"milestone_views" gorm:"not null;uniqueIndex:idx_news_milestone"`
+	Status    MilestonePayoutStatus `json:"status" gorm:"type:varchar(20);not null"`
+	Amount    Money                 `json:"amount" gorm:"type:decimal(15,2);not null"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+// Reward cap settings, in the same decimal format as Money. Empty or zero
+// means no cap.
+const (
+	SettingRewardDailyCap   = "reward.daily_cap"
+	SettingRewardMonthlyCap = "reward.monthly_cap"
+)
+
+// RewardSuggestion is the reward computed for an article and how it was
+// reached. It is returned in the pending queue and stored with the payout.
+type RewardSuggestion struct {
+	Amount         Money  `json:"amount"`
+	PolicyID       uint   `json:"policy_id,omitempty"`
+	Base           Money  `json:"base"`
+	WordCount      int    `json:"word_count"`
+	WordCountBonus Money  `json:"word_count_bonus"`
+	ThumbnailBonus Money  `json:"thumbnail_bonus"`
+	Uncapped       Money  `json:"uncapped"`
+	CappedBy       string `json:"capped_by,omitempty"` // daily atau monthly
+	Manual         bool   `json:"manual,omitempty"`    // Jumlah diisi manual oleh admin
+	Milestone      int    `json:"milestone_views,omitempty"`
+}
+
+// JSON returns the suggestion as stored in BalanceEntry.Rule
+func (s RewardSuggestion) JSON() string {
+	raw, _ := json.Marshal(s)
+	return string(raw)
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Setting is a runtime-configurable key/value pair managed from the admin panel
+type Setting struct {
+	Key       string    `json:"key" gorm:"primaryKey;type:varchar(100)"`
+	Value     string    `json:"value" gorm:"type:text"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+const (
+	// SettingRequireTwoFactor forces every admin/editor to enroll in 2FA
+	SettingRequireTwoFactor = "security.require_two_factor"
+
+	// Publisher submission limits; 0 means unlimited. Publishers with at
+	// least SettingPublisherTrustedAfter approved articles get the trusted
+	// limits.
+	SettingPublisherMaxPending          = "publisher.max_pending"
+	SettingPublisherDailySubmissions    = "publisher.daily_submissions"
+	SettingPublisherTrustedAfter        = "publisher.trusted_after"
+	SettingPublisherTrustedMaxPending   = "publisher.trusted_max_pending"
+	SettingPublisherTrustedDailySubmits = "publisher.trusted_daily_submissions"
+
+	// SettingPublisherCreateTags lets publishers add tags that do not exist
+	// yet by name when they submit articles. Staff can always create tags.
+	SettingPublisherCreateTags = "publisher.create_tags"
+
+	// SettingTrashRetentionDays is how many days deleted articles stay in
+	// the trash before they are purged; 0 keeps them until purged by hand
+	SettingTrashRetentionDays = "trash.retention_days"
+
+	// Content quality rules checked when articles are created or edited;
+	// 0 disables a rule. SettingContentBannedWords is a list, one word or
+	// phrase per line.
+	SettingContentTitleMaxWords      = "content.title_max_words"
+	SettingContentTitleMinChars      = "content.title_min_chars"
+	SettingContentTitleMaxChars      = "content.title_max_chars"
+	SettingContentExcerptMaxWords    = "content.excerpt_max_words"
+	SettingContentMinWords           = "content.min_words"
+	SettingContentThumbnailMinWidth  = "content.thumbnail_min_width"
+	SettingContentThumbnailMinHeight = "content.thumbnail_min_height"
+	SettingContentMaxLinks           = "content.max_links"
+	SettingContentMinTags            = "content.min_tags"
+	SettingContentBannedWords        = "content.banned_words"
+)
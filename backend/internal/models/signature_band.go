@@ -0,0 +1,33 @@
+package models
+
+import (
+	"xinxun-news/internal/similarity"
+
+	"gorm.io/gorm"
+)
+
+// SignatureBand indexes one band of an article's content signature, so
+// articles resembling a submission are found through the bands they share
+// instead of by comparing every stored signature
+type SignatureBand struct {
+	NewsID uint   `json:"news_id" gorm:"primaryKey;autoIncrement:false"`
+	Band   uint8  `json:"band" gorm:"primaryKey;autoIncrement:false;index:idx_band_value,priority:1"`
+	Value  uint64 `json:"value" gorm:"not null;index:idx_band_value,priority:2"`
+}
+
+// ReplaceSignatureBands stores the bands of signature for one article,
+// replacing the previous ones
+func ReplaceSignatureBands(tx *gorm.DB, newsID uint, signature string) error {
+	if err := tx.Where("news_id = ?", newsID).Delete(&SignatureBand{}).Error; err != nil {
+		return err
+	}
+	values := similarity.Bands(signature)
+	if len(values) == 0 {
+		return nil
+	}
+	bands := make([]SignatureBand, 0, len(values))
+	for i, value := range values {
+		bands = append(bands, SignatureBand{NewsID: newsID, Band: uint8(i), Value: value})
+	}
+	return tx.Create(&bands).Error
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// SimilarMatch is an existing article that resembles a submission
+type SimilarMatch struct {
+	NewsID uint    `json:"news_id"`
+	Title  string  `json:"title"`
+	Slug   string  `json:"slug"`
+	Status string  `json:"status"`
+	Score  float64 `json:"score"`
+}
+
+// SimilarMatches lists the closest matches of a submission, best first.
+// Stored as a JSON string column.
+type SimilarMatches []SimilarMatch
+
+func (m SimilarMatches) Value() (driver.Value, error) {
+	if len(m) == 0 {
+		return "", nil
+	}
+	raw, err := json.Marshal(m)
+	return string(raw), err
+}
+
+func (m *SimilarMatches) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return errors.New("unsupported type for SimilarMatches")
+	}
+	if len(raw) == 0 {
+		*m = nil
+		return nil
+	}
+	return json.Unmarshal(raw, m)
+}
@@ -9,6 +9,7 @@ import (
 type Tag struct {
 	ID        uint           `json:"id" gorm:"primaryKey"`
 	Name      string         `json:"name" gorm:"not null"`
+	Names     LocalizedNames `json:"names" gorm:"type:text"` // Nama dalam bahasa lain
 	Slug      string         `json:"slug" gorm:"unique;not null"`
 	Order     int            `json:"order" gorm:"default:0"` // Urutan tampilan
 	CreatedAt time.Time      `json:"created_at"`
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// TagRedirect keeps the slug of a tag that was merged into another tag so
+// old links still resolve
+type TagRedirect struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Slug      string    `json:"slug" gorm:"type:varchar(255);uniqueIndex;not null"`
+	TagID     uint      `json:"tag_id" gorm:"index;not null"` // Tag tujuan
+	CreatedAt time.Time `json:"created_at"`
+}
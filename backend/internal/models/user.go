@@ -1,6 +1,9 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"time"
 
 	"gorm.io/gorm"
@@ -10,23 +13,96 @@ type UserType string
 
 const (
 	UserTypeAdmin     UserType = "admin"
+	UserTypeEditor    UserType = "editor" // Staff yang dapat mengelola artikel tapi tidak mengelola user
 	UserTypePublisher UserType = "publisher"
 )
 
+const (
+	UserStatusActive  = "Active"
+	UserStatusSuspend = "Suspend"
+)
+
+// IsStaff reports whether the user type belongs to the newsroom (admin or editor)
+func (t UserType) IsStaff() bool {
+	return t == UserTypeAdmin || t == UserTypeEditor
+}
+
 type User struct {
 	ID           uint           `json:"id" gorm:"primaryKey"`
 	Username     string         `json:"username" gorm:"unique;not null"`
 	Name         string         `json:"name" gorm:"not null"`
 	Email        string         `json:"email" gorm:"unique;not null"`
 	PasswordHash string         `json:"-" gorm:"not null"`
-	UserType     UserType       `json:"user_type" gorm:"type:enum('admin','publisher');default:'admin'"`
+	UserType     UserType       `json:"user_type" gorm:"type:enum('admin','editor','publisher');default:'admin'"`
 	XinxunID     *uint          `json:"xinxun_id"` // ID dari xinxun.us API
 	XinxunNumber string         `json:"xinxun_number"` // Nomor telepon dari xinxun
-	Balance      float64        `json:"balance" gorm:"default:0"`
+	Balance      Money          `json:"balance" gorm:"type:decimal(15,2);default:0"` // Saldo dari ledger (balance_entries), jangan diubah langsung
 	Status       string         `json:"status" gorm:"default:'Active'"` // Active/Suspend
 	ReffCode     string         `json:"reff_code"`
+	MustChangePassword bool     `json:"must_change_password" gorm:"default:false"` // Wajib ganti password saat login berikutnya
+	EmailVerifiedAt    *time.Time `json:"email_verified_at"`
+	TwoFactorEnabled   bool       `json:"two_factor_enabled" gorm:"default:false"`
+	TwoFactorSecret    string     `json:"-"` // TOTP secret (base32), diisi saat enrollment
+	TwoFactorLastStep  int64      `json:"-" gorm:"default:0"` // Step TOTP terakhir yang dipakai, mencegah replay
+	TokenVersion       uint       `json:"-" gorm:"default:0"` // Naik setiap ganti password, token JWT versi lama ditolak
+	DisplayName  string         `json:"display_name"` // Nama yang tampil di halaman author
+	Bio          string         `json:"bio" gorm:"type:text"`
+	AvatarURL    string         `json:"avatar_url"`
+	SocialLinks  SocialLinks    `json:"social_links" gorm:"type:text"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
+
+// SocialLinks maps a network name (twitter, instagram, ...) to a profile URL.
+// Stored as a JSON string column.
+type SocialLinks map[string]string
+
+func (s SocialLinks) Value() (driver.Value, error) {
+	if len(s) == 0 {
+		return "", nil
+	}
+	raw, err := json.Marshal(s)
+	return string(raw), err
+}
+
+func (s *SocialLinks) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return errors.New("unsupported type for SocialLinks")
+	}
+	if len(raw) == 0 {
+		*s = nil
+		return nil
+	}
+	return json.Unmarshal(raw, s)
+}
+
+// IsActive reports whether the account is allowed to log in
+func (u *User) IsActive() bool {
+	return u.Status == "" || u.Status == UserStatusActive
+}
+
+// SetPassword stores a new password hash and revokes every session token
+// issued before the change
+func (u *User) SetPassword(hash string) {
+	u.PasswordHash = hash
+	u.TokenVersion++
+}
+
+// PublicName returns the display name when set, otherwise the account name
+func (u *User) PublicName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	return u.Name
+}
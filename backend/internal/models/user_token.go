@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type TokenPurpose string
+
+const (
+	TokenPurposePasswordReset     TokenPurpose = "password_reset"
+	TokenPurposeEmailVerification TokenPurpose = "email_verification"
+)
+
+// UserToken is a single-use token sent by email. Only the SHA-256 hash of
+// the token is stored.
+type UserToken struct {
+	ID        uint         `json:"id" gorm:"primaryKey"`
+	UserID    uint         `json:"user_id" gorm:"index;not null"`
+	Purpose   TokenPurpose `json:"purpose" gorm:"type:varchar(32);index;not null"`
+	TokenHash string       `json:"-" gorm:"type:char(64);uniqueIndex;not null"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    *time.Time   `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
@@ -0,0 +1,61 @@
+package ratelimit
+
+import "time"
+
+// Lockout locks an account or IP after repeated login failures. Every
+// failure past Threshold doubles the lock duration, up to MaxLock.
+type Lockout struct {
+	Threshold int
+	BaseLock  time.Duration
+	MaxLock   time.Duration
+	Window    time.Duration // How long failures are remembered
+}
+
+// DefaultLockout locks after 5 failures for 1 minute, doubling up to 1 hour
+var DefaultLockout = Lockout{
+	Threshold: 5,
+	BaseLock:  time.Minute,
+	MaxLock:   time.Hour,
+	Window:    24 * time.Hour,
+}
+
+func (l Lockout) duration(failures int) time.Duration {
+	if failures < l.Threshold {
+		return 0
+	}
+	lock := l.BaseLock
+	for i := l.Threshold; i < failures && lock < l.MaxLock; i++ {
+		lock *= 2
+	}
+	if lock > l.MaxLock {
+		lock = l.MaxLock
+	}
+	return lock
+}
+
+// Check returns how long key stays locked, or zero when attempts are allowed
+func (l Lockout) Check(key string) (time.Duration, error) {
+	failures, last, err := store.Failures("lockout:"+key, l.Window)
+	if err != nil || failures == 0 {
+		return 0, err
+	}
+	remaining := time.Until(last.Add(l.duration(failures)))
+	if remaining < 0 {
+		return 0, nil
+	}
+	return remaining, nil
+}
+
+// Fail records a failed attempt and returns the resulting lock duration
+func (l Lockout) Fail(key string) (time.Duration, error) {
+	failures, err := store.RecordFailure("lockout:"+key, l.Window)
+	if err != nil {
+		return 0, err
+	}
+	return l.duration(failures), nil
+}
+
+// Succeed clears the failures of key after a successful login
+func (l Lockout) Succeed(key string) error {
+	return store.Reset("lockout:" + key)
+}
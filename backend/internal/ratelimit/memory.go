@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens   float64
+	updated  time.Time
+	failures []time.Time
+	window   time.Duration // Lockout window of the failures
+}
+
+// MemoryStore is a process-local Store. Idle entries are swept periodically.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}, swept: time.Now()}
+}
+
+func (s *MemoryStore) get(key string, now time.Time) *bucket {
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: -1, updated: now}
+		s.buckets[key] = b
+	}
+	return b
+}
+
+func (s *MemoryStore) Take(key string, rate Rate) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+	b := s.get(key, now)
+
+	capacity := float64(rate.Requests)
+	perToken := rate.Period / time.Duration(rate.Requests)
+
+	if b.tokens < 0 {
+		b.tokens = capacity
+	} else {
+		elapsed := now.Sub(b.updated)
+		b.tokens = math.Min(capacity, b.tokens+elapsed.Seconds()/perToken.Seconds())
+	}
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+
+	wait := time.Duration((1 - b.tokens) * float64(perToken))
+	return false, wait, nil
+}
+
+func (s *MemoryStore) RecordFailure(key string, window time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	b := s.get(key, now)
+	b.failures = append(pruneFailures(b.failures, now, window), now)
+	b.window = window
+	b.updated = now
+	return len(b.failures), nil
+}
+
+func (s *MemoryStore) Failures(key string, window time.Duration) (int, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		return 0, time.Time{}, nil
+	}
+	b.failures = pruneFailures(b.failures, time.Now(), window)
+	if len(b.failures) == 0 {
+		return 0, time.Time{}, nil
+	}
+	return len(b.failures), b.failures[len(b.failures)-1], nil
+}
+
+func (s *MemoryStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if b, ok := s.buckets[key]; ok {
+		b.failures = nil
+	}
+	return nil
+}
+
+// sweep drops entries untouched for an hour whose failures have left the
+// lockout window, at most once a minute
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.swept) < time.Minute {
+		return
+	}
+	s.swept = now
+	for key, b := range s.buckets {
+		b.failures = pruneFailures(b.failures, now, b.window)
+		if now.Sub(b.updated) > time.Hour && len(b.failures) == 0 {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func pruneFailures(failures []time.Time, now time.Time, window time.Duration) []time.Time {
+	kept := failures[:0]
+	for _, at := range failures {
+		if now.Sub(at) < window {
+			kept = append(kept, at)
+		}
+	}
+	return kept
+}
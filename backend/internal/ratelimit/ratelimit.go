@@ -0,0 +1,67 @@
+// Package ratelimit implements token-bucket rate limiting and progressive
+// login lockout on top of a pluggable Store. MemoryStore is used by default;
+// a shared store (e.g. Redis) can be plugged in with SetStore when the API
+// runs on several instances.
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rate allows Requests per Period, refilled continuously
+type Rate struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseRate parses values such as "60/1m" or "10/30s"
+func ParseRate(value string) (Rate, error) {
+	parts := strings.SplitN(strings.TrimSpace(value), "/", 2)
+	if len(parts) != 2 {
+		return Rate{}, fmt.Errorf("invalid rate %q, expected <requests>/<duration>", value)
+	}
+
+	requests, err := strconv.Atoi(parts[0])
+	if err != nil || requests < 1 {
+		return Rate{}, fmt.Errorf("invalid request count in rate %q", value)
+	}
+
+	period, err := time.ParseDuration(parts[1])
+	if err != nil || period <= 0 {
+		return Rate{}, fmt.Errorf("invalid period in rate %q", value)
+	}
+
+	return Rate{Requests: requests, Period: period}, nil
+}
+
+// Store keeps limiter state. Implementations must be safe for concurrent use.
+type Store interface {
+	// Take consumes one token from the bucket at key. When the bucket is
+	// empty it returns false and how long until a token is available.
+	Take(key string, rate Rate) (bool, time.Duration, error)
+
+	// RecordFailure adds a failure at key and returns the number of failures
+	// within window, including this one
+	RecordFailure(key string, window time.Duration) (int, error)
+
+	// Failures returns the failure count within window and the time of the last failure
+	Failures(key string, window time.Duration) (int, time.Time, error)
+
+	// Reset clears the failures at key
+	Reset(key string) error
+}
+
+var store Store = NewMemoryStore()
+
+// SetStore replaces the store used by the middleware and lockout helpers
+func SetStore(s Store) {
+	store = s
+}
+
+// Allow consumes a token for key from the active store
+func Allow(key string, rate Rate) (bool, time.Duration, error) {
+	return store.Take(key, rate)
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"time"
+
+	"xinxun-news/internal/database"
+	"xinxun-news/internal/models"
+)
+
+type AuditLogRepository struct{}
+
+func NewAuditLogRepository() *AuditLogRepository {
+	return &AuditLogRepository{}
+}
+
+// AuditLogFilter describes an audit log search. Zero values are ignored.
+type AuditLogFilter struct {
+	ActorID    uint
+	Actor      string // username
+	Action     string
+	TargetType string
+	TargetID   uint
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+	Cursor     *Cursor
+}
+
+func (r *AuditLogRepository) Create(log *models.AuditLog) error {
+	return database.DB.Create(log).Error
+}
+
+// FindAll returns one page of audit logs, newest first, the total matching
+// rows and the cursor of the next page
+func (r *AuditLogRepository) FindAll(filter AuditLogFilter) ([]models.AuditLog, int64, *Cursor, error) {
+	var logs []models.AuditLog
+	var total int64
+
+	query := database.DB.Model(&models.AuditLog{})
+
+	if filter.ActorID != 0 {
+		query = query.Where("audit_logs.actor_id = ?", filter.ActorID)
+	}
+
+	if filter.Actor != "" {
+		query = query.Joins("JOIN users ON users.id = audit_logs.actor_id").
+			Where("users.username = ?", filter.Actor)
+	}
+
+	if filter.Action != "" {
+		query = query.Where("audit_logs.action = ?", filter.Action)
+	}
+
+	if filter.TargetType != "" {
+		query = query.Where("audit_logs.target_type = ?", filter.TargetType)
+	}
+
+	if filter.TargetID != 0 {
+		query = query.Where("audit_logs.target_id = ?", filter.TargetID)
+	}
+
+	if filter.From != nil {
+		query = query.Where("audit_logs.created_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("audit_logs.created_at < ?", *filter.To)
+	}
+
+	query.Count(&total)
+
+	if filter.Cursor != nil {
+		query = query.Where("audit_logs.created_at < ? OR (audit_logs.created_at = ? AND audit_logs.id < ?)",
+			filter.Cursor.CreatedAt, filter.Cursor.CreatedAt, filter.Cursor.ID)
+	} else if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	err := query.Preload("Actor").
+		Order("audit_logs.created_at DESC, audit_logs.id DESC").
+		Limit(filter.Limit + 1).
+		Find(&logs).Error
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var next *Cursor
+	if len(logs) > filter.Limit {
+		logs = logs[:filter.Limit]
+		last := logs[len(logs)-1]
+		next = &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return logs, total, next, nil
+}
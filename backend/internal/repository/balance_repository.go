@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"time"
+
+	"xinxun-news/internal/database"
+	"xinxun-news/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BalanceRepository struct{}
+
+func NewBalanceRepository() *BalanceRepository {
+	return &BalanceRepository{}
+}
+
+// Record appends entry to the user's ledger and updates User.Balance in the
+// same transaction. The user row is locked so concurrent entries cannot
+// compute the same running balance.
+func (r *BalanceRepository) Record(entry *models.BalanceEntry) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "balance").First(&user, entry.UserID).Error; err != nil {
+			return err
+		}
+
+		var current models.Money
+		if err := tx.Model(&models.BalanceEntry{}).
+			Where("user_id = ?", entry.UserID).
+			Select("COALESCE(SUM(amount), 0)").Scan(&current).Error; err != nil {
+			return err
+		}
+
+		entry.BalanceAfter = current + entry.Amount
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.User{}).Where("id = ?", entry.UserID).
+			Update("balance", entry.BalanceAfter).Error
+	})
+}
+
+// Balance returns the ledger sum for a user
+func (r *BalanceRepository) Balance(userID uint) (models.Money, error) {
+	var balance models.Money
+	err := database.DB.Model(&models.BalanceEntry{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(SUM(amount), 0)").Scan(&balance).Error
+	return balance, err
+}
+
+// SyncedBalance returns the ledger sum for a user without the entries that
+// exist only locally (manual adjustments), i.e. the part of the balance
+// that xinxun.us also knows about
+func (r *BalanceRepository) SyncedBalance(userID uint) (models.Money, error) {
+	var balance models.Money
+	err := database.DB.Model(&models.BalanceEntry{}).
+		Where("user_id = ? AND type <> ?", userID, models.BalanceEntryAdjustment).
+		Select("COALESCE(SUM(amount), 0)").Scan(&balance).Error
+	return balance, err
+}
+
+// SumRewardsSince sums the reward and milestone entries of a user since a
+// point in time, used to enforce reward caps
+func (r *BalanceRepository) SumRewardsSince(userID uint, since time.Time) (models.Money, error) {
+	var total models.Money
+	err := database.DB.Model(&models.BalanceEntry{}).
+		Where("user_id = ? AND type IN ? AND created_at >= ?", userID,
+			[]models.BalanceEntryType{models.BalanceEntryReward, models.BalanceEntryMilestone}, since).
+		Select("COALESCE(SUM(amount), 0)").Scan(&total).Error
+	return total, err
+}
+
+// FindByUser returns one page of ledger entries, newest first. A cursor
+// takes precedence over offset; next is set when more entries follow.
+func (r *BalanceRepository) FindByUser(userID uint, limit, offset int, cursor *Cursor) ([]models.BalanceEntry, int64, *Cursor, error) {
+	var entries []models.BalanceEntry
+	var total int64
+
+	query := database.DB.Model(&models.BalanceEntry{}).Where("user_id = ?", userID)
+	query.Count(&total)
+
+	if cursor != nil {
+		query = query.Where("created_at < ? OR (created_at = ? AND id < ?)", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	} else if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err := query.Preload("News", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Select("id", "title", "slug")
+	}).
+		Order("created_at DESC, id DESC").
+		Limit(limit + 1).
+		Find(&entries).Error
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var next *Cursor
+	if len(entries) > limit {
+		entries = entries[:limit]
+		last := entries[len(entries)-1]
+		next = &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return entries, total, next, nil
+}
+
+// ArticleEarning is the total reward paid for one article
+type ArticleEarning struct {
+	NewsID     uint         `json:"news_id"`
+	Title      string       `json:"title"`
+	Slug       string       `json:"slug"`
+	Amount     models.Money `json:"amount"`
+	RewardedAt time.Time    `json:"rewarded_at"`
+}
+
+// RewardsByArticle sums reward and milestone entries per article, newest first
+func (r *BalanceRepository) RewardsByArticle(userID uint) ([]ArticleEarning, error) {
+	var earnings []ArticleEarning
+	err := database.DB.Table("balance_entries").
+		Select("balance_entries.news_id, news.title, news.slug, SUM(balance_entries.amount) AS amount, "+
+			"MAX(balance_entries.created_at) AS rewarded_at").
+		Joins("LEFT JOIN news ON news.id = balance_entries.news_id").
+		Where("balance_entries.user_id = ? AND balance_entries.type IN ? AND balance_entries.news_id IS NOT NULL",
+			userID, []models.BalanceEntryType{models.BalanceEntryReward, models.BalanceEntryMilestone}).
+		Group("balance_entries.news_id, news.title, news.slug").
+		Order("MAX(balance_entries.created_at) DESC").
+		Scan(&earnings).Error
+	return earnings, err
+}
+
+// MonthlyEarning is the ledger total of one month, split by entry type
+type MonthlyEarning struct {
+	Month       string       `json:"month"`   // YYYY-MM
+	Rewards     models.Money `json:"rewards"` // Termasuk bonus milestone
+	Adjustments models.Money `json:"adjustments"`
+	Corrections models.Money `json:"sync_corrections"`
+	Total       models.Money `json:"total"`
+}
+
+// TotalsByMonth sums the ledger per calendar month, newest first
+func (r *BalanceRepository) TotalsByMonth(userID uint) ([]MonthlyEarning, error) {
+	var months []MonthlyEarning
+	err := database.DB.Table("balance_entries").
+		Select("DATE_FORMAT(created_at, '%Y-%m') AS month, "+
+			"COALESCE(SUM(CASE WHEN type IN ? THEN amount END), 0) AS rewards, "+
+			"COALESCE(SUM(CASE WHEN type = ? THEN amount END), 0) AS adjustments, "+
+			"COALESCE(SUM(CASE WHEN type = ? THEN amount END), 0) AS corrections, "+
+			"SUM(amount) AS total",
+			[]models.BalanceEntryType{models.BalanceEntryReward, models.BalanceEntryMilestone},
+			models.BalanceEntryAdjustment, models.BalanceEntrySyncCorrection).
+		Where("user_id = ?", userID).
+		Group("month").
+		Order("month DESC").
+		Scan(&months).Error
+	return months, err
+}
@@ -50,12 +50,68 @@ func (r *CategoryRepository) FindByID(id uint) (*models.Category, error) {
 	return &category, err
 }
 
+// FindDescendantIDs returns the ID of a category followed by the IDs of
+// every category below it, at any depth
+func (r *CategoryRepository) FindDescendantIDs(id uint) ([]uint, error) {
+	var categories []models.Category
+	if err := database.DB.Select("id", "parent_id").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	children := make(map[uint][]uint)
+	for _, category := range categories {
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+		}
+	}
+
+	ids := []uint{id}
+	seen := map[uint]bool{id: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// FindPath returns the ancestors of a category from the top-level category
+// down to the category itself
+func (r *CategoryRepository) FindPath(id uint) ([]models.Category, error) {
+	var categories []models.Category
+	if err := database.DB.Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	var path []models.Category
+	seen := make(map[uint]bool)
+	current, ok := byID[id]
+	for ok && !seen[current.ID] {
+		seen[current.ID] = true
+		path = append([]models.Category{current}, path...)
+		if current.ParentID == nil {
+			break
+		}
+		current, ok = byID[*current.ParentID]
+	}
+	return path, nil
+}
+
 func (r *CategoryRepository) Create(category *models.Category) error {
 	// Check if there's a soft-deleted category with the same slug
 	existing, err := r.FindBySlugUnscoped(category.Slug)
 	if err == nil && existing.DeletedAt.Valid {
 		// Restore the soft-deleted category
 		existing.Name = category.Name
+		existing.Names = category.Names
+		existing.ParentID = category.ParentID
 		existing.IsAdminOnly = category.IsAdminOnly
 		if category.Order > 0 {
 			existing.Order = category.Order
@@ -77,6 +133,18 @@ func (r *CategoryRepository) Create(category *models.Category) error {
 	return database.DB.Create(category).Error
 }
 
+// Reorder moves the listed categories to the front of the display order
+// among the children of parentID (nil = the top-level categories). Other
+// levels of the tree keep their order.
+func (r *CategoryRepository) Reorder(parentID *uint, ids []uint) error {
+	return reorder(&models.Category{}, ids, func(db *gorm.DB) *gorm.DB {
+		if parentID == nil {
+			return db.Where("parent_id IS NULL")
+		}
+		return db.Where("parent_id = ?", *parentID)
+	})
+}
+
 func (r *CategoryRepository) Update(category *models.Category) error {
 	return database.DB.Save(category).Error
 }
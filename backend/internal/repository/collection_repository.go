@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"time"
+
+	"xinxun-news/internal/database"
+	"xinxun-news/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CollectionRepository struct{}
+
+func NewCollectionRepository() *CollectionRepository {
+	return &CollectionRepository{}
+}
+
+func (r *CollectionRepository) FindAll() ([]models.Collection, error) {
+	var collections []models.Collection
+	err := database.DB.Order("name ASC").Find(&collections).Error
+	return collections, err
+}
+
+// FindByID returns a collection with its items by position. The articles of
+// the items are loaded without their content.
+func (r *CollectionRepository) FindByID(id uint) (*models.Collection, error) {
+	var collection models.Collection
+	err := database.DB.
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Items.News", func(db *gorm.DB) *gorm.DB {
+			return db.Omit("content", "content_signature")
+		}).
+		First(&collection, id).Error
+	return &collection, err
+}
+
+func (r *CollectionRepository) FindBySlug(slug string) (*models.Collection, error) {
+	var collection models.Collection
+	err := database.DB.Where("slug = ?", slug).First(&collection).Error
+	return &collection, err
+}
+
+func (r *CollectionRepository) Create(collection *models.Collection) error {
+	return database.DB.Create(collection).Error
+}
+
+func (r *CollectionRepository) Update(collection *models.Collection) error {
+	return database.DB.Omit("Items").Save(collection).Error
+}
+
+// Delete removes a collection and its items
+func (r *CollectionRepository) Delete(id uint) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", id).Delete(&models.CollectionItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Collection{}, id).Error
+	})
+}
+
+// ReplaceItems swaps the items of a collection for the given ones, which are
+// stored in order as positions 1..n
+func (r *CollectionRepository) ReplaceItems(collectionID uint, items []models.CollectionItem) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", collectionID).Delete(&models.CollectionItem{}).Error; err != nil {
+			return err
+		}
+		for i := range items {
+			items[i].ID = 0
+			items[i].CollectionID = collectionID
+			items[i].Position = i + 1
+			if err := tx.Omit("News").Create(&items[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindActiveNews returns the published articles of a collection whose
+// display window includes at, by position. An empty lang includes every
+// language.
+func (r *CollectionRepository) FindActiveNews(collectionID uint, lang string, limit int, at time.Time) ([]models.News, error) {
+	var news []models.News
+	query := database.DB.Model(&models.News{}).
+		Joins("JOIN collection_items ON collection_items.news_id = news.id").
+		Where("collection_items.collection_id = ? AND news.status = ?", collectionID, models.StatusPublished).
+		Where("collection_items.starts_at IS NULL OR collection_items.starts_at <= ?", at).
+		Where("collection_items.expires_at IS NULL OR collection_items.expires_at > ?", at)
+	if lang != "" {
+		query = query.Where("news.language = ?", lang)
+	}
+	err := query.Omit("content", "content_signature").
+		Preload("Category").Preload("Author").Preload("Tags").
+		Order("collection_items.position ASC").
+		Limit(limit).
+		Find(&news).Error
+	return news, err
+}
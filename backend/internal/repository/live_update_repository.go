@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"time"
+
+	"xinxun-news/internal/database"
+	"xinxun-news/internal/models"
+)
+
+type LiveUpdateRepository struct{}
+
+func NewLiveUpdateRepository() *LiveUpdateRepository {
+	return &LiveUpdateRepository{}
+}
+
+// FindByID returns one entry of a live blog
+func (r *LiveUpdateRepository) FindByID(newsID, id uint) (*models.LiveUpdate, error) {
+	var update models.LiveUpdate
+	err := database.DB.Preload("Author").
+		Where("news_id = ?", newsID).
+		First(&update, id).Error
+	return &update, err
+}
+
+// FindLatest returns the newest entries of a live blog, older than the
+// (created_at, id) cursor when it is set
+func (r *LiveUpdateRepository) FindLatest(newsID uint, before *Cursor, limit int) ([]models.LiveUpdate, error) {
+	var updates []models.LiveUpdate
+	query := database.DB.Preload("Author").Where("news_id = ?", newsID)
+	if before != nil {
+		query = query.Where("created_at < ? OR (created_at = ? AND id < ?)",
+			before.CreatedAt, before.CreatedAt, before.ID)
+	}
+	err := query.Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&updates).Error
+	return updates, err
+}
+
+// FindChangedAfter returns the entries created or edited after the cursor,
+// oldest change first. The cursor holds updated_at in CreatedAt, so
+// entries changed in the same second are paged by id.
+func (r *LiveUpdateRepository) FindChangedAfter(newsID uint, after Cursor, limit int) ([]models.LiveUpdate, error) {
+	var updates []models.LiveUpdate
+	err := database.DB.Preload("Author").
+		Where("news_id = ?", newsID).
+		Where("updated_at > ? OR (updated_at = ? AND id > ?)", after.CreatedAt, after.CreatedAt, after.ID).
+		Order("updated_at ASC, id ASC").
+		Limit(limit).
+		Find(&updates).Error
+	return updates, err
+}
+
+// FindDeletedSince returns the ids of the entries deleted at or after since
+func (r *LiveUpdateRepository) FindDeletedSince(newsID uint, since time.Time) ([]uint, error) {
+	ids := []uint{}
+	err := database.DB.Unscoped().Model(&models.LiveUpdate{}).
+		Where("news_id = ? AND deleted_at >= ?", newsID, since).
+		Order("id ASC").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// FindContents returns the content of every entry of a live blog, deleted
+// ones included
+func (r *LiveUpdateRepository) FindContents(newsID uint) ([]string, error) {
+	var contents []string
+	err := database.DB.Unscoped().Model(&models.LiveUpdate{}).
+		Where("news_id = ?", newsID).
+		Pluck("content", &contents).Error
+	return contents, err
+}
+
+func (r *LiveUpdateRepository) Count(newsID uint) (int64, error) {
+	var count int64
+	err := database.DB.Model(&models.LiveUpdate{}).Where("news_id = ?", newsID).Count(&count).Error
+	return count, err
+}
+
+func (r *LiveUpdateRepository) Create(update *models.LiveUpdate) error {
+	return database.DB.Omit("Author").Create(update).Error
+}
+
+func (r *LiveUpdateRepository) Update(update *models.LiveUpdate) error {
+	return database.DB.Omit("Author").Save(update).Error
+}
+
+func (r *LiveUpdateRepository) Delete(id uint) error {
+	return database.DB.Delete(&models.LiveUpdate{}, id).Error
+}
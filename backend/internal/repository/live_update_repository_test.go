@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"xinxun-news/internal/database"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// dryRunDB swaps database.DB for a MySQL session that builds statements
+// without a server, and returns the SQL and vars of every query it runs
+func dryRunDB(t *testing.T) func() (string, []interface{}) {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "user:pass@tcp(127.0.0.1:3306)/test",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open dry run db: %v", err)
+	}
+
+	var sql string
+	var vars []interface{}
+	db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		if sql == "" {
+			sql = tx.Statement.SQL.String()
+			vars = tx.Statement.Vars
+		}
+	})
+
+	old := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = old })
+	return func() (string, []interface{}) { return sql, vars }
+}
+
+func TestFindChangedAfterPagesByUpdatedAtThenID(t *testing.T) {
+	captured := dryRunDB(t)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if _, err := NewLiveUpdateRepository().FindChangedAfter(9, Cursor{CreatedAt: at, ID: 42}, 21); err != nil {
+		t.Fatalf("FindChangedAfter: %v", err)
+	}
+	sql, vars := captured()
+
+	for _, want := range []string{
+		"news_id = ?",
+		"(updated_at > ? OR (updated_at = ? AND id > ?))",
+		"`live_updates`.`deleted_at` IS NULL",
+		"ORDER BY updated_at ASC, id ASC",
+		"LIMIT 21",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("query is missing %q:\n%s", want, sql)
+		}
+	}
+
+	// news_id, then updated_at twice and the id of the cursor
+	if len(vars) != 4 {
+		t.Fatalf("vars = %v, want 4", vars)
+	}
+	if vars[0] != uint(9) {
+		t.Errorf("news_id = %v, want 9", vars[0])
+	}
+	for _, i := range []int{1, 2} {
+		if got, ok := vars[i].(time.Time); !ok || !got.Equal(at) {
+			t.Errorf("vars[%d] = %v, want %v", i, vars[i], at)
+		}
+	}
+	if vars[3] != uint(42) {
+		t.Errorf("id = %v, want 42", vars[3])
+	}
+}
@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
 	"xinxun-news/internal/database"
 	"xinxun-news/internal/models"
+	"xinxun-news/internal/similarity"
 
 	"gorm.io/gorm"
 )
@@ -13,48 +17,277 @@ func NewNewsRepository() *NewsRepository {
 	return &NewsRepository{}
 }
 
-func (r *NewsRepository) FindAll(limit, offset int, search, category string, status *models.NewsStatus) ([]models.News, int64, error) {
+// NewsFilter describes a news list query. When Cursor is set, keyset
+// pagination is used and Offset is ignored.
+type NewsFilter struct {
+	Search         string
+	Category       string // Category slug, includes its subcategories
+	Author         string // Author username
+	AuthorID       uint
+	Status         *models.NewsStatus // nil = all statuses (admin/publisher view)
+	IsRevision     *bool              // nil = both originals and revisions
+	Language       string             // empty = all languages
+	Pinned         *bool              // true = only active pins, false = leave them out, nil = both
+	ExcludeIDs     []uint
+	Limit          int
+	Offset         int
+	Cursor         *Cursor
+	WithoutContent bool // Skip loading content for list payloads
+}
+
+// FindAll returns one page of news, the total matching rows and the cursor
+// of the next page (nil when this is the last page)
+func (r *NewsRepository) FindAll(filter NewsFilter) ([]models.News, int64, *Cursor, error) {
 	var news []models.News
 	var total int64
 
 	query := database.DB.Model(&models.News{})
 
-	if search != "" {
-		query = query.Where("title LIKE ? OR excerpt LIKE ?", "%"+search+"%", "%"+search+"%")
+	if filter.Search != "" {
+		query = query.Where("title LIKE ? OR excerpt LIKE ?", "%"+filter.Search+"%", "%"+filter.Search+"%")
+	}
+
+	if filter.Category != "" {
+		// A category lists the news of its subcategories as well
+		categoryRepo := NewCategoryRepository()
+		category, err := categoryRepo.FindBySlug(filter.Category)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return []models.News{}, 0, nil, nil
+		}
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		categoryIDs, err := categoryRepo.FindDescendantIDs(category.ID)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		query = query.Where("news.category_id IN ?", categoryIDs)
+	}
+
+	if filter.Author != "" {
+		query = query.Joins("JOIN users ON users.id = news.author_id").
+			Where("users.username = ?", filter.Author)
 	}
 
-	if category != "" {
-		query = query.Joins("JOIN categories ON categories.id = news.category_id").
-			Where("categories.slug = ?", category)
+	if filter.AuthorID != 0 {
+		query = query.Where("news.author_id = ?", filter.AuthorID)
 	}
 
-	if status != nil {
+	if filter.Status != nil {
 		// Filter by specific status (public view - only published)
-		query = query.Where("status = ?", *status)
+		query = query.Where("news.status = ?", *filter.Status)
+	}
+
+	if filter.Language != "" {
+		query = query.Where("news.language = ?", filter.Language)
+	}
+
+	if filter.Pinned != nil {
+		active := "news.is_pinned = ? AND (news.pinned_until IS NULL OR news.pinned_until > ?)"
+		if *filter.Pinned {
+			query = query.Where(active, true, time.Now())
+		} else {
+			query = query.Not(active, true, time.Now())
+		}
+	}
+
+	if len(filter.ExcludeIDs) > 0 {
+		query = query.Where("news.id NOT IN ?", filter.ExcludeIDs)
+	}
+
+	if filter.IsRevision != nil {
+		if *filter.IsRevision {
+			query = query.Where("news.revision_of IS NOT NULL")
+		} else {
+			query = query.Where("news.revision_of IS NULL")
+		}
 	}
-	// If status is nil, show all statuses (admin/publisher view)
 
 	query.Count(&total)
 
+	if filter.Cursor != nil {
+		query = query.Where("news.created_at < ? OR (news.created_at = ? AND news.id < ?)",
+			filter.Cursor.CreatedAt, filter.Cursor.CreatedAt, filter.Cursor.ID)
+	} else if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	if filter.WithoutContent {
+		query = query.Omit("content", "content_signature")
+	}
+
+	// Fetch one extra row to know whether another page exists
 	err := query.Preload("Category").Preload("Author").Preload("Tags").
-		Order("created_at DESC").
-		Limit(limit).Offset(offset).
+		Order("news.created_at DESC, news.id DESC").
+		Limit(filter.Limit + 1).
+		Find(&news).Error
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var next *Cursor
+	if len(news) > filter.Limit {
+		news = news[:filter.Limit]
+		last := news[len(news)-1]
+		next = &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return news, total, next, nil
+}
+
+// CountByAuthorAndStatus counts the articles and revisions of one author
+// with the given status
+func (r *NewsRepository) CountByAuthorAndStatus(authorID uint, status models.NewsStatus) (int64, error) {
+	var count int64
+	err := database.DB.Model(&models.News{}).
+		Where("author_id = ? AND status = ?", authorID, status).
+		Count(&count).Error
+	return count, err
+}
+
+// CountSubmittedSince counts the articles and revisions an author created
+// since a point in time, including deleted ones
+func (r *NewsRepository) CountSubmittedSince(authorID uint, since time.Time) (int64, error) {
+	var count int64
+	err := database.DB.Unscoped().Model(&models.News{}).
+		Where("author_id = ? AND created_at >= ?", authorID, since).
+		Count(&count).Error
+	return count, err
+}
+
+// FindSignatures loads the id, title, slug, status and content signature of
+// the articles sharing at least one signature band with signature, the
+// candidates worth comparing. excludeID skips one article; when original is
+// set, that article and all of its revisions are skipped as well.
+func (r *NewsRepository) FindSignatures(signature string, excludeID uint, original *uint) ([]models.News, error) {
+	bands := similarity.Bands(signature)
+	if len(bands) == 0 {
+		return nil, nil
+	}
+	pairs := make([][]interface{}, 0, len(bands))
+	for i, value := range bands {
+		pairs = append(pairs, []interface{}{i, value})
+	}
+
+	var news []models.News
+	query := database.DB.Select("id", "title", "slug", "status", "content_signature").
+		Where("content_signature <> ''").
+		Where("id IN (?)", database.DB.Model(&models.SignatureBand{}).
+			Select("DISTINCT news_id").Where("(band, value) IN ?", pairs))
+	if excludeID != 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if original != nil {
+		query = query.Where("id <> ? AND (revision_of IS NULL OR revision_of <> ?)", *original, *original)
+	}
+	err := query.Find(&news).Error
+	return news, err
+}
+
+// CountPublishedByAuthor counts the published articles of one author
+func (r *NewsRepository) CountPublishedByAuthor(authorID uint) (int64, error) {
+	var count int64
+	err := database.DB.Model(&models.News{}).
+		Where("author_id = ? AND status = ?", authorID, models.StatusPublished).
+		Count(&count).Error
+	return count, err
+}
+
+// FindTopViews gets top viewed news for featured section, leaving out
+// excludeIDs. An empty lang includes every language.
+func (r *NewsRepository) FindTopViews(limit int, lang string, excludeIDs []uint) ([]models.News, error) {
+	var news []models.News
+	query := database.DB.Preload("Category").Preload("Author").Preload("Tags").
+		Where("status = ?", models.StatusPublished)
+	if lang != "" {
+		query = query.Where("language = ?", lang)
+	}
+	if len(excludeIDs) > 0 {
+		query = query.Where("id NOT IN ?", excludeIDs)
+	}
+	err := query.Order("views DESC, created_at DESC").
+		Limit(limit).
 		Find(&news).Error
+	return news, err
+}
 
-	return news, total, err
+// FindFeatured returns the published articles editors marked as featured
+// and whose featured period has not ended, by featured_order
+func (r *NewsRepository) FindFeatured(limit int, lang string) ([]models.News, error) {
+	var news []models.News
+	query := database.DB.Preload("Category").Preload("Author").Preload("Tags").
+		Where("status = ? AND is_featured = ?", models.StatusPublished, true).
+		Where("featured_until IS NULL OR featured_until > ?", time.Now())
+	if lang != "" {
+		query = query.Where("language = ?", lang)
+	}
+	err := query.Order("featured_order ASC, published_at DESC").
+		Limit(limit).
+		Find(&news).Error
+	return news, err
 }
 
-// FindTopViews gets top viewed news for featured section
-func (r *NewsRepository) FindTopViews(limit int) ([]models.News, error) {
+// UpdateCuration saves the pinned and featured flags of an article
+func (r *NewsRepository) UpdateCuration(news *models.News) error {
+	return database.DB.Model(news).
+		Select("is_pinned", "pinned_until", "is_featured", "featured_order", "featured_until").
+		Updates(news).Error
+}
+
+// FindBreaking returns the published articles flagged as breaking news
+// whose flag has not expired, newest first
+func (r *NewsRepository) FindBreaking(limit int, lang string) ([]models.News, error) {
 	var news []models.News
-	err := database.DB.Preload("Category").Preload("Author").Preload("Tags").
-		Where("status = ?", models.StatusPublished).
-		Order("views DESC, created_at DESC").
+	query := database.DB.Omit("content", "content_signature").
+		Preload("Category").Preload("Author").Preload("Tags").
+		Where("status = ? AND is_breaking = ?", models.StatusPublished, true).
+		Where("breaking_until IS NULL OR breaking_until > ?", time.Now())
+	if lang != "" {
+		query = query.Where("language = ?", lang)
+	}
+	err := query.Order("published_at DESC, id DESC").
 		Limit(limit).
 		Find(&news).Error
 	return news, err
 }
 
+// UpdateBreaking saves the breaking news flag of an article
+func (r *NewsRepository) UpdateBreaking(news *models.News) error {
+	return database.DB.Model(news).
+		Select("is_breaking", "breaking_until").
+		Updates(news).Error
+}
+
+// SetType changes the article type
+func (r *NewsRepository) SetType(id uint, newsType models.NewsType) error {
+	return database.DB.Model(&models.News{}).Where("id = ?", id).
+		UpdateColumn("type", newsType).Error
+}
+
+// Touch moves updated_at of an article to now, e.g. when a live update is
+// posted
+func (r *NewsRepository) Touch(id uint) error {
+	return database.DB.Model(&models.News{}).Where("id = ?", id).
+		UpdateColumn("updated_at", time.Now()).Error
+}
+
+// FindTranslations returns the articles of a translation group, without
+// their content. Pending revisions are left out.
+func (r *NewsRepository) FindTranslations(groupID uint) ([]models.News, error) {
+	var news []models.News
+	err := database.DB.Select("id", "title", "slug", "language", "status", "revision_of", "translation_group_id").
+		Where("translation_group_id = ? AND revision_of IS NULL", groupID).
+		Order("language ASC").
+		Find(&news).Error
+	return news, err
+}
+
+// SetTranslationGroup moves one article into a translation group
+func (r *NewsRepository) SetTranslationGroup(id uint, groupID *uint) error {
+	return database.DB.Model(&models.News{}).Where("id = ?", id).
+		UpdateColumn("translation_group_id", groupID).Error
+}
+
 // FindNewest gets newest published news ordered by created_at DESC
 func (r *NewsRepository) FindNewest(limit int) ([]models.News, error) {
 	var news []models.News
@@ -81,6 +314,15 @@ func (r *NewsRepository) FindByID(id uint) (*models.News, error) {
 	return &news, err
 }
 
+// FindByIDs returns the articles with the given ids, without their content
+func (r *NewsRepository) FindByIDs(ids []uint) ([]models.News, error) {
+	var news []models.News
+	err := database.DB.Omit("content", "content_signature").
+		Where("id IN ?", ids).
+		Find(&news).Error
+	return news, err
+}
+
 func (r *NewsRepository) Create(news *models.News) error {
 	return database.DB.Create(news).Error
 }
@@ -93,6 +335,141 @@ func (r *NewsRepository) Delete(id uint) error {
 	return database.DB.Delete(&models.News{}, id).Error
 }
 
+// ApplyRevision saves the original article updated with an approved
+// revision and deletes the revision, in one transaction
+func (r *NewsRepository) ApplyRevision(original *models.News, revisionID uint) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(original).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.News{}, revisionID).Error
+	})
+}
+
+// SetCategory moves one article to another category
+func (r *NewsRepository) SetCategory(id, categoryID uint) error {
+	return database.DB.Model(&models.News{}).Where("id = ?", id).
+		UpdateColumn("category_id", categoryID).Error
+}
+
+// SetStatus changes the status of one article
+func (r *NewsRepository) SetStatus(id uint, status models.NewsStatus) error {
+	return database.DB.Model(&models.News{}).Where("id = ?", id).
+		UpdateColumn("status", status).Error
+}
+
+// AddTags adds tags to an article, keeping the tags it already has
+func (r *NewsRepository) AddTags(news *models.News, tags []models.Tag) error {
+	return database.DB.Model(news).Association("Tags").Append(tags)
+}
+
+// RemoveTags removes tags from an article
+func (r *NewsRepository) RemoveTags(news *models.News, tags []models.Tag) error {
+	return database.DB.Model(news).Association("Tags").Delete(tags)
+}
+
+// FindTrashed returns one page of soft-deleted articles, most recently
+// deleted first, without their content. The trash is ordered by deletion
+// time, so the cursor's CreatedAt holds deleted_at.
+func (r *NewsRepository) FindTrashed(limit, offset int, cursor *Cursor) ([]models.News, int64, *Cursor, error) {
+	var news []models.News
+	var total int64
+	query := database.DB.Unscoped().Model(&models.News{}).Where("news.deleted_at IS NOT NULL")
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, nil, err
+	}
+	if cursor != nil {
+		query = query.Where("news.deleted_at < ? OR (news.deleted_at = ? AND news.id < ?)", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	} else if offset > 0 {
+		query = query.Offset(offset)
+	}
+	err := query.Preload("Category").Preload("Author").Preload("Tags").
+		Omit("content", "content_signature").
+		Order("news.deleted_at DESC, news.id DESC").
+		Limit(limit + 1).
+		Find(&news).Error
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var next *Cursor
+	if len(news) > limit {
+		news = news[:limit]
+		last := news[len(news)-1]
+		next = &Cursor{CreatedAt: last.DeletedAt.Time, ID: last.ID}
+	}
+	return news, total, next, nil
+}
+
+// FindTrashedByID loads one soft-deleted article
+func (r *NewsRepository) FindTrashedByID(id uint) (*models.News, error) {
+	var news models.News
+	err := database.DB.Unscoped().Preload("Category").Preload("Author").Preload("Tags").
+		Where("deleted_at IS NOT NULL").
+		First(&news, id).Error
+	return &news, err
+}
+
+// FindTrashedBefore returns up to limit articles deleted before a point in
+// time
+func (r *NewsRepository) FindTrashedBefore(before time.Time, limit int) ([]models.News, error) {
+	var news []models.News
+	err := database.DB.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Find(&news).Error
+	return news, err
+}
+
+// Restore takes an article out of the trash
+func (r *NewsRepository) Restore(id uint) error {
+	return database.DB.Unscoped().Model(&models.News{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		UpdateColumn("deleted_at", nil).Error
+}
+
+// Purge permanently deletes an article, its tag links, its places in
+// collections, its signature bands and its live updates. Ledger entries
+// of the article stay, unlinked from it.
+func (r *NewsRepository) Purge(id uint) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM news_tags WHERE news_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM collection_items WHERE news_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM signature_bands WHERE news_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM live_updates WHERE news_id = ?", id).Error; err != nil {
+			return err
+		}
+		// Ledger entries are kept; their reason still names the article
+		if err := tx.Exec("UPDATE balance_entries SET news_id = NULL WHERE news_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&models.News{}, id).Error
+	})
+}
+
+// CountMediaReferences counts the articles other than excludeID, deleted
+// ones included, whose thumbnail, content or live updates use a media URL
+func (r *NewsRepository) CountMediaReferences(url string, excludeID uint) (int64, error) {
+	var count int64
+	err := database.DB.Unscoped().Model(&models.News{}).
+		Where("id <> ? AND (thumbnail = ? OR content LIKE ?)", excludeID, url, "%"+url+"%").
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return count, err
+	}
+	err = database.DB.Unscoped().Model(&models.LiveUpdate{}).
+		Where("news_id <> ? AND content LIKE ?", excludeID, "%"+url+"%").
+		Count(&count).Error
+	return count, err
+}
+
 func (r *NewsRepository) IncrementViews(id uint) error {
 	return database.DB.Model(&models.News{}).Where("id = ?", id).
 		UpdateColumn("views", gorm.Expr("views + 1")).Error
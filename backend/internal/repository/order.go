@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"xinxun-news/internal/database"
+
+	"gorm.io/gorm"
+)
+
+// reorder gives the listed ids the first display positions, in the given
+// order. Rows that are not listed keep their relative order after them.
+// When scope is set, only the rows it selects are renumbered, e.g. the
+// siblings under one parent.
+func reorder(model interface{}, ids []uint, scope func(*gorm.DB) *gorm.DB) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(model)
+		if scope != nil {
+			query = scope(query)
+		}
+		var current []uint
+		if err := query.Order("`order` ASC, id ASC").Pluck("id", &current).Error; err != nil {
+			return err
+		}
+
+		listed := make(map[uint]bool, len(ids))
+		for _, id := range ids {
+			listed[id] = true
+		}
+		sequence := append([]uint{}, ids...)
+		for _, id := range current {
+			if !listed[id] {
+				sequence = append(sequence, id)
+			}
+		}
+
+		for i, id := range sequence {
+			if err := tx.Model(model).Where("id = ?", id).UpdateColumn("order", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"time"
+
+	"xinxun-news/internal/database"
+	"xinxun-news/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type RecoveryCodeRepository struct{}
+
+func NewRecoveryCodeRepository() *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{}
+}
+
+// Replace deletes the user's existing codes and stores the new hashes
+func (r *RecoveryCodeRepository) Replace(userID uint, codeHashes []string) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		codes := make([]models.RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, models.RecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// Use marks an unused code as used. It returns false when no such code exists.
+func (r *RecoveryCodeRepository) Use(userID uint, codeHash string) (bool, error) {
+	result := database.DB.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *RecoveryCodeRepository) CountUnused(userID uint) (int64, error) {
+	var count int64
+	err := database.DB.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *RecoveryCodeRepository) DeleteForUser(userID uint) error {
+	return database.DB.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"xinxun-news/internal/database"
+	"xinxun-news/internal/models"
+
+	"gorm.io/gorm/clause"
+)
+
+type RewardRepository struct{}
+
+func NewRewardRepository() *RewardRepository {
+	return &RewardRepository{}
+}
+
+func (r *RewardRepository) FindPolicies() ([]models.RewardPolicy, error) {
+	var policies []models.RewardPolicy
+	err := database.DB.Preload("Category").
+		Order("category_id IS NOT NULL, category_id ASC").
+		Find(&policies).Error
+	return policies, err
+}
+
+func (r *RewardRepository) FindPolicyByID(id uint) (*models.RewardPolicy, error) {
+	var policy models.RewardPolicy
+	err := database.DB.Preload("Category").First(&policy, id).Error
+	return &policy, err
+}
+
+// FindPolicyForCategory returns the category's own policy, falling back to
+// the default policy. It returns nil when neither exists.
+func (r *RewardRepository) FindPolicyForCategory(categoryID uint) (*models.RewardPolicy, error) {
+	var policies []models.RewardPolicy
+	err := database.DB.Where("category_id = ? OR category_id IS NULL", categoryID).
+		Order("category_id IS NULL").
+		Limit(1).
+		Find(&policies).Error
+	if err != nil || len(policies) == 0 {
+		return nil, err
+	}
+	return &policies[0], nil
+}
+
+// FindDefaultPolicy returns the policy without a category, if any
+func (r *RewardRepository) FindDefaultPolicy() (*models.RewardPolicy, error) {
+	var policies []models.RewardPolicy
+	err := database.DB.Where("category_id IS NULL").Limit(1).Find(&policies).Error
+	if err != nil || len(policies) == 0 {
+		return nil, err
+	}
+	return &policies[0], nil
+}
+
+func (r *RewardRepository) CreatePolicy(policy *models.RewardPolicy) error {
+	return database.DB.Create(policy).Error
+}
+
+func (r *RewardRepository) UpdatePolicy(policy *models.RewardPolicy) error {
+	return database.DB.Omit("Category").Save(policy).Error
+}
+
+func (r *RewardRepository) DeletePolicy(id uint) error {
+	return database.DB.Delete(&models.RewardPolicy{}, id).Error
+}
+
+func (r *RewardRepository) FindMilestones() ([]models.RewardMilestone, error) {
+	var milestones []models.RewardMilestone
+	err := database.DB.Order("views ASC").Find(&milestones).Error
+	return milestones, err
+}
+
+func (r *RewardRepository) FindMilestoneByID(id uint) (*models.RewardMilestone, error) {
+	var milestone models.RewardMilestone
+	err := database.DB.First(&milestone, id).Error
+	return &milestone, err
+}
+
+func (r *RewardRepository) CreateMilestone(milestone *models.RewardMilestone) error {
+	return database.DB.Create(milestone).Error
+}
+
+func (r *RewardRepository) UpdateMilestone(milestone *models.RewardMilestone) error {
+	return database.DB.Save(milestone).Error
+}
+
+func (r *RewardRepository) DeleteMilestone(id uint) error {
+	return database.DB.Delete(&models.RewardMilestone{}, id).Error
+}
+
+// FindMilestoneCandidates returns published publisher articles that reached
+// views but have not been paid the bonus of that milestone yet
+func (r *RewardRepository) FindMilestoneCandidates(views, limit int) ([]models.News, error) {
+	var news []models.News
+	err := database.DB.Model(&models.News{}).
+		Omit("content").
+		Joins("JOIN users ON users.id = news.author_id").
+		Where("news.status = ? AND news.revision_of IS NULL AND news.views >= ?", models.StatusPublished, views).
+		Where("users.user_type = ? AND users.xinxun_id IS NOT NULL", models.UserTypePublisher).
+		Where("NOT EXISTS (SELECT 1 FROM balance_entries WHERE balance_entries.news_id = news.id "+
+			"AND balance_entries.type = ? AND balance_entries.milestone = ?)", models.BalanceEntryMilestone, views).
+		Where("NOT EXISTS (SELECT 1 FROM milestone_payouts WHERE milestone_payouts.news_id = news.id "+
+			"AND milestone_payouts.milestone = ?)", views).
+		Preload("Author").
+		Order("news.id ASC").
+		Limit(limit).
+		Find(&news).Error
+	return news, err
+}
+
+// ClaimMilestone records that the bonus of a milestone is about to be paid
+// for an article. It returns nil when the milestone was already claimed.
+func (r *RewardRepository) ClaimMilestone(newsID uint, views int, amount models.Money) (*models.MilestonePayout, error) {
+	payout := &models.MilestonePayout{
+		NewsID:    newsID,
+		Milestone: views,
+		Status:    models.MilestonePayoutPending,
+		Amount:    amount,
+	}
+	result := database.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(payout)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return payout, nil
+}
+
+// MarkMilestonePaid finalizes a claimed milestone once it was paid
+func (r *RewardRepository) MarkMilestonePaid(payout *models.MilestonePayout) error {
+	payout.Status = models.MilestonePayoutPaid
+	return database.DB.Model(payout).Update("status", payout.Status).Error
+}
+
+// ReleaseMilestone drops a claim whose payment failed, so the next run
+// tries again
+func (r *RewardRepository) ReleaseMilestone(payout *models.MilestonePayout) error {
+	return database.DB.Delete(payout).Error
+}
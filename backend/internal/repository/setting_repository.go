@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+
+	"xinxun-news/internal/database"
+	"xinxun-news/internal/models"
+
+	"gorm.io/gorm/clause"
+)
+
+type SettingRepository struct{}
+
+func NewSettingRepository() *SettingRepository {
+	return &SettingRepository{}
+}
+
+// Get returns the value of key, or fallback when it is not set
+func (r *SettingRepository) Get(key, fallback string) string {
+	var setting models.Setting
+	if err := database.DB.Where("`key` = ?", key).First(&setting).Error; err != nil {
+		return fallback
+	}
+	return setting.Value
+}
+
+func (r *SettingRepository) GetBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(r.Get(key, strconv.FormatBool(fallback)))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// Set creates or updates key
+func (r *SettingRepository) Set(key, value string) error {
+	return database.DB.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&models.Setting{Key: key, Value: value}).Error
+}
+
+func (r *SettingRepository) SetBool(key string, value bool) error {
+	return r.Set(key, strconv.FormatBool(value))
+}
+
+func (r *SettingRepository) GetInt(key string, fallback int) int {
+	value, err := strconv.Atoi(r.Get(key, strconv.Itoa(fallback)))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func (r *SettingRepository) SetInt(key string, value int) error {
+	return r.Set(key, strconv.Itoa(value))
+}
+
+// GetMoney returns a decimal amount setting, or zero when it is not set or
+// invalid
+func (r *SettingRepository) GetMoney(key string) models.Money {
+	value, err := models.ParseMoney(r.Get(key, "0"))
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+func (r *SettingRepository) SetMoney(key string, value models.Money) error {
+	return r.Set(key, value.String())
+}
+
+// GetList returns a list setting stored one item per line. Commas also
+// separate items; empty items are skipped.
+func (r *SettingRepository) GetList(key string) []string {
+	var list []string
+	for _, item := range strings.FieldsFunc(r.Get(key, ""), func(r rune) bool {
+		return r == '\n' || r == ','
+	}) {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (r *SettingRepository) SetList(key string, values []string) error {
+	return r.Set(key, strings.Join(values, "\n"))
+}
@@ -5,6 +5,7 @@ import (
 	"xinxun-news/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TagRepository struct{}
@@ -31,6 +32,39 @@ func (r *TagRepository) FindBySlug(slug string) (*models.Tag, error) {
 	return &tag, err
 }
 
+// FindByIDs loads the tags with the given ids; missing ids are left out
+func (r *TagRepository) FindByIDs(ids []uint) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := database.DB.Where("id IN ?", ids).Find(&tags).Error
+	return tags, err
+}
+
+// FindBySlugs loads the tags with the given slugs; missing slugs are left out
+func (r *TagRepository) FindBySlugs(slugs []string) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := database.DB.Where("slug IN ?", slugs).Find(&tags).Error
+	return tags, err
+}
+
+// Search finds tags whose name or slug contains query, for autocomplete.
+// Names starting with the query come first, then the most used tags.
+func (r *TagRepository) Search(query, querySlug string, limit int) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := database.DB.Model(&models.Tag{}).
+		Select("tags.*").
+		Joins("LEFT JOIN news_tags ON news_tags.tag_id = tags.id").
+		Where("tags.name LIKE ? OR tags.slug LIKE ?", "%"+query+"%", "%"+querySlug+"%").
+		Group("tags.id").
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "tags.name LIKE ? DESC, COUNT(news_tags.news_id) DESC, tags.name ASC",
+			Vars:               []interface{}{query + "%"},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Find(&tags).Error
+	return tags, err
+}
+
 // FindBySlugUnscoped finds tag by slug including soft-deleted ones
 func (r *TagRepository) FindBySlugUnscoped(slug string) (*models.Tag, error) {
 	var tag models.Tag
@@ -64,6 +98,66 @@ func (r *TagRepository) Create(tag *models.Tag) error {
 	return database.DB.Create(tag).Error
 }
 
+// FindRedirect finds the tag a merged slug now points to
+func (r *TagRepository) FindRedirect(slug string) (*models.Tag, error) {
+	var redirect models.TagRedirect
+	if err := database.DB.Where("slug = ?", slug).First(&redirect).Error; err != nil {
+		return nil, err
+	}
+	return r.FindByID(redirect.TagID)
+}
+
+// Reorder moves the listed tags to the front of the display order
+func (r *TagRepository) Reorder(ids []uint) error {
+	return reorder(&models.Tag{}, ids, nil)
+}
+
+// Merge moves the articles of the source tags to the target tag, deletes
+// the source tags and leaves a redirect from each source slug. It returns
+// the number of articles that gained the target tag.
+func (r *TagRepository) Merge(sources []models.Tag, targetID uint) (int64, error) {
+	var moved int64
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		for _, source := range sources {
+			// Articles that already have the target tag only lose the source tag
+			var tagged []uint
+			if err := tx.Table("news_tags").Where("tag_id = ?", targetID).Pluck("news_id", &tagged).Error; err != nil {
+				return err
+			}
+			query := tx.Table("news_tags").Where("tag_id = ?", source.ID)
+			if len(tagged) > 0 {
+				query = query.Where("news_id NOT IN ?", tagged)
+			}
+			result := query.Update("tag_id", targetID)
+			if result.Error != nil {
+				return result.Error
+			}
+			moved += result.RowsAffected
+			if err := tx.Exec("DELETE FROM news_tags WHERE tag_id = ?", source.ID).Error; err != nil {
+				return err
+			}
+
+			// Earlier redirects to the source follow it to the target
+			if err := tx.Model(&models.TagRedirect{}).Where("tag_id = ?", source.ID).
+				Update("tag_id", targetID).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("slug = ?", source.Slug).Delete(&models.TagRedirect{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&models.TagRedirect{Slug: source.Slug, TagID: targetID}).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Delete(&models.Tag{}, source.ID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return moved, err
+}
+
 func (r *TagRepository) Update(tag *models.Tag) error {
 	return database.DB.Save(tag).Error
 }
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"time"
+
+	"xinxun-news/internal/database"
+	"xinxun-news/internal/models"
+)
+
+type TokenRepository struct{}
+
+func NewTokenRepository() *TokenRepository {
+	return &TokenRepository{}
+}
+
+func (r *TokenRepository) Create(token *models.UserToken) error {
+	return database.DB.Create(token).Error
+}
+
+// FindValid finds an unused, unexpired token by its hash
+func (r *TokenRepository) FindValid(purpose models.TokenPurpose, tokenHash string) (*models.UserToken, error) {
+	var token models.UserToken
+	err := database.DB.
+		Where("purpose = ? AND token_hash = ? AND used_at IS NULL AND expires_at > ?", purpose, tokenHash, time.Now()).
+		First(&token).Error
+	return &token, err
+}
+
+func (r *TokenRepository) MarkUsed(token *models.UserToken) error {
+	now := time.Now()
+	token.UsedAt = &now
+	return database.DB.Model(token).Update("used_at", now).Error
+}
+
+// InvalidateForUser marks every outstanding token of a purpose as used so
+// only the newest one works
+func (r *TokenRepository) InvalidateForUser(userID uint, purpose models.TokenPurpose) error {
+	return database.DB.Model(&models.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
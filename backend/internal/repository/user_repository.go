@@ -1,10 +1,18 @@
 package repository
 
 import (
+	"errors"
+
 	"xinxun-news/internal/database"
 	"xinxun-news/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrLastActiveAdmin is returned when a change would leave no active admin
+var ErrLastActiveAdmin = errors.New("last active admin")
+
 type UserRepository struct{}
 
 func NewUserRepository() *UserRepository {
@@ -76,8 +84,10 @@ func (r *UserRepository) FirstOrCreate(user *models.User, username string, xinxu
 	return &existing, nil
 }
 
+// Update saves the user. Balance is left out: it is only changed through
+// BalanceRepository.Record so a stale copy cannot overwrite the ledger sum.
 func (r *UserRepository) Update(user *models.User) error {
-	return database.DB.Save(user).Error
+	return database.DB.Omit("balance").Save(user).Error
 }
 
 func (r *UserRepository) FindByXinxunNumber(number string) (*models.User, error) {
@@ -92,3 +102,93 @@ func (r *UserRepository) FindByID(id uint) (*models.User, error) {
 	return &user, err
 }
 
+
+// FindPublishers returns one page of publisher accounts, newest first. A
+// cursor takes precedence over offset; next is set when more follow.
+func (r *UserRepository) FindPublishers(limit, offset int, cursor *Cursor) ([]models.User, int64, *Cursor, error) {
+	var users []models.User
+	var total int64
+
+	query := database.DB.Model(&models.User{}).Where("user_type = ?", models.UserTypePublisher)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, nil, err
+	}
+
+	if cursor != nil {
+		query = query.Where("created_at < ? OR (created_at = ? AND id < ?)", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	} else if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err := query.Order("created_at DESC, id DESC").Limit(limit + 1).Find(&users).Error
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var next *Cursor
+	if len(users) > limit {
+		users = users[:limit]
+		last := users[len(users)-1]
+		next = &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return users, total, next, nil
+}
+
+// FindStaff returns admin and editor accounts. Staff accounts are few, so
+// the list is not paginated.
+func (r *UserRepository) FindStaff() ([]models.User, error) {
+	var users []models.User
+	err := database.DB.Where("user_type IN ?", []models.UserType{models.UserTypeAdmin, models.UserTypeEditor}).
+		Order("id ASC").Find(&users).Error
+	return users, err
+}
+
+// UpdateKeepingAdmin saves a staff account like Update, refusing with
+// ErrLastActiveAdmin when it demotes or suspends the last active admin
+func (r *UserRepository) UpdateKeepingAdmin(user *models.User) error {
+	stillAdmin := user.UserType == models.UserTypeAdmin && user.IsActive()
+	return r.keepingAdmin(user.ID, stillAdmin, func(tx *gorm.DB) error {
+		return tx.Omit("balance").Save(user).Error
+	})
+}
+
+// DeleteKeepingAdmin soft-deletes a staff account, refusing with
+// ErrLastActiveAdmin when it is the last active admin
+func (r *UserRepository) DeleteKeepingAdmin(user *models.User) error {
+	return r.keepingAdmin(user.ID, false, func(tx *gorm.DB) error {
+		return tx.Delete(user).Error
+	})
+}
+
+// keepingAdmin runs apply in a transaction that first locks the active
+// admin rows. Concurrent changes to admins wait for each other, so two
+// demotions cannot both count the other admin and remove the last one.
+func (r *UserRepository) keepingAdmin(userID uint, stillAdmin bool, apply func(tx *gorm.DB) error) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		if err := tx.Model(&models.User{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_type = ? AND (status = ? OR status = '')", models.UserTypeAdmin, models.UserStatusActive).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+
+		if !stillAdmin {
+			isAdmin, others := false, 0
+			for _, id := range ids {
+				if id == userID {
+					isAdmin = true
+				} else {
+					others++
+				}
+			}
+			if isAdmin && others == 0 {
+				return ErrLastActiveAdmin
+			}
+		}
+		return apply(tx)
+	})
+}
+
+func (r *UserRepository) Delete(user *models.User) error {
+	return database.DB.Delete(user).Error
+}
@@ -0,0 +1,108 @@
+package rewards
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+	"xinxun-news/internal/services"
+)
+
+// milestoneBatchSize bounds the articles paid per milestone in one run
+const milestoneBatchSize = 100
+
+// StartMilestoneWorker pays view-milestone bonuses every interval until the
+// process exits
+func StartMilestoneWorker(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if paid, err := PayMilestones(); err != nil {
+				log.Printf("[Rewards] Milestone run failed: %v", err)
+			} else if paid > 0 {
+				log.Printf("[Rewards] Paid %d milestone bonuses", paid)
+			}
+		}
+	}()
+}
+
+// PayMilestones pays the bonus of every milestone reached by a published
+// publisher article that has not been paid yet. It returns the number of
+// bonuses paid. Each bonus is claimed before it is sent to xinxun.us, so a
+// bonus that was sent is never sent again, even when its ledger entry
+// cannot be written.
+func PayMilestones() (int, error) {
+	rewardRepo := repository.NewRewardRepository()
+	balanceRepo := repository.NewBalanceRepository()
+
+	milestones, err := rewardRepo.FindMilestones()
+	if err != nil {
+		return 0, err
+	}
+
+	paid := 0
+	for _, milestone := range milestones {
+		candidates, err := rewardRepo.FindMilestoneCandidates(milestone.Views, milestoneBatchSize)
+		if err != nil {
+			return paid, err
+		}
+
+		for _, news := range candidates {
+			suggestion := &models.RewardSuggestion{
+				Base:      milestone.Bonus,
+				Uncapped:  milestone.Bonus,
+				Amount:    milestone.Bonus,
+				Milestone: milestone.Views,
+			}
+			if err := ApplyCaps(suggestion, news.AuthorID, time.Now()); err != nil {
+				return paid, err
+			}
+
+			payout, err := rewardRepo.ClaimMilestone(news.ID, milestone.Views, suggestion.Amount)
+			if err != nil {
+				return paid, err
+			}
+			if payout == nil {
+				continue // Claimed by another run
+			}
+
+			// A capped bonus is still recorded (with amount 0) so the
+			// milestone is not retried on every run
+			if suggestion.Amount > 0 {
+				resp, err := services.SendReward(*news.Author.XinxunID, suggestion.Amount.Float64())
+				if err != nil || !resp.Success {
+					log.Printf("[Rewards] Milestone %d for news %d not paid: %v", milestone.Views, news.ID, err)
+					if err := rewardRepo.ReleaseMilestone(payout); err != nil {
+						return paid, err
+					}
+					continue
+				}
+			}
+			if err := rewardRepo.MarkMilestonePaid(payout); err != nil {
+				log.Printf("[Rewards] Milestone %d for news %d paid but not marked: %v", milestone.Views, news.ID, err)
+			}
+
+			newsID := news.ID
+			entry := &models.BalanceEntry{
+				UserID:    news.AuthorID,
+				Type:      models.BalanceEntryMilestone,
+				Amount:    suggestion.Amount,
+				NewsID:    &newsID,
+				Reason:    fmt.Sprintf("Bonus %d views: %s", milestone.Views, news.Title),
+				Rule:      suggestion.JSON(),
+				Milestone: milestone.Views,
+			}
+			if err := balanceRepo.Record(entry); err != nil {
+				log.Printf("[Rewards] Milestone %d for news %d paid but not recorded in the ledger: %v", milestone.Views, news.ID, err)
+				return paid, err
+			}
+			if suggestion.Amount > 0 {
+				paid++
+			}
+		}
+	}
+	return paid, nil
+}
@@ -0,0 +1,76 @@
+// Package rewards computes publisher rewards from the configured reward
+// policies, milestones and caps.
+package rewards
+
+import (
+	"time"
+
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+)
+
+// Suggest computes the reward for approving news: the category's policy
+// (or the default policy) plus word count and thumbnail bonuses, reduced to
+// what is left under the author's daily and monthly caps.
+func Suggest(news *models.News) (*models.RewardSuggestion, error) {
+	suggestion := &models.RewardSuggestion{WordCount: news.WordCount}
+
+	policy, err := repository.NewRewardRepository().FindPolicyForCategory(news.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	if policy != nil {
+		suggestion.PolicyID = policy.ID
+		suggestion.Base = policy.BaseAmount
+		suggestion.WordCountBonus = policy.WordCountTiers.Bonus(news.WordCount)
+		if news.Thumbnail != "" {
+			suggestion.ThumbnailBonus = policy.ThumbnailBonus
+		}
+	}
+
+	suggestion.Uncapped = suggestion.Base + suggestion.WordCountBonus + suggestion.ThumbnailBonus
+	suggestion.Amount = suggestion.Uncapped
+
+	if err := ApplyCaps(suggestion, news.AuthorID, time.Now()); err != nil {
+		return nil, err
+	}
+	return suggestion, nil
+}
+
+// ApplyCaps lowers suggestion.Amount to what the author can still earn
+// today and this month, and records which cap applied
+func ApplyCaps(suggestion *models.RewardSuggestion, authorID uint, now time.Time) error {
+	settingRepo := repository.NewSettingRepository()
+	balanceRepo := repository.NewBalanceRepository()
+
+	caps := []struct {
+		name  string
+		key   string
+		since time.Time
+	}{
+		{"daily", models.SettingRewardDailyCap, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())},
+		{"monthly", models.SettingRewardMonthlyCap, time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())},
+	}
+
+	for _, window := range caps {
+		limit := settingRepo.GetMoney(window.key)
+		if limit <= 0 {
+			continue
+		}
+
+		paid, err := balanceRepo.SumRewardsSince(authorID, window.since)
+		if err != nil {
+			return err
+		}
+
+		remaining := limit - paid
+		if remaining < 0 {
+			remaining = 0
+		}
+		if suggestion.Amount > remaining {
+			suggestion.Amount = remaining
+			suggestion.CappedBy = window.name
+		}
+	}
+	return nil
+}
@@ -4,8 +4,11 @@ import (
 	"log"
 	"os"
 	"time"
+	"xinxun-news/internal/apierror"
+	"xinxun-news/internal/config"
 	"xinxun-news/internal/handlers"
 	"xinxun-news/internal/middleware"
+	"xinxun-news/internal/ratelimit"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -14,14 +17,20 @@ import (
 func SetupRoutes() *gin.Engine {
 	r := gin.Default()
 
+	// The client IP feeds rate limits, login lockouts and the audit log, so
+	// X-Forwarded-For is only read from the configured proxies
+	if err := r.SetTrustedProxies(config.AppConfig.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	// CORS configuration
-	config := cors.DefaultConfig()
+	corsConfig := cors.DefaultConfig()
 	corsOrigin := os.Getenv("CORS_ORIGIN")
 	if corsOrigin == "" {
 		corsOrigin = "http://localhost:3000"
 	}
 	// Allow multiple origins for development and production
-	config.AllowOrigins = []string{
+	corsConfig.AllowOrigins = []string{
 		corsOrigin,
 		"http://localhost:3000",
 		"http://127.0.0.1:3000",
@@ -30,8 +39,8 @@ func SetupRoutes() *gin.Engine {
 		"https://xinxun.us",
 		"https://www.xinxun.us",
 	}
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH", "HEAD"}
-	config.AllowHeaders = []string{
+	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH", "HEAD"}
+	corsConfig.AllowHeaders = []string{
 		"Origin",
 		"Content-Type",
 		"Authorization",
@@ -40,43 +49,65 @@ func SetupRoutes() *gin.Engine {
 		"Access-Control-Request-Method",
 		"Access-Control-Request-Headers",
 	}
-	config.AllowCredentials = true
-	config.ExposeHeaders = []string{"Content-Length", "Content-Type"}
-	config.MaxAge = 12 * time.Hour
-	r.Use(cors.New(config))
+	corsConfig.AllowCredentials = true
+	corsConfig.ExposeHeaders = []string{"Content-Length", "Content-Type", "Retry-After"}
+	corsConfig.MaxAge = 12 * time.Hour
+	r.Use(cors.New(corsConfig))
 
 	// Log CORS config for debugging
-	log.Printf("CORS configured - Allowed Origins: %v", config.AllowOrigins)
+	log.Printf("CORS configured - Allowed Origins: %v", corsConfig.AllowOrigins)
 
 	// Health check endpoint
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok", "message": "Backend is running"})
 	})
 
+	// Unknown routes get the same error body as every other endpoint
+	r.NoRoute(func(c *gin.Context) {
+		apierror.Respond(c, apierror.NotFound(apierror.CodeRouteNotFound))
+	})
+
+	// Rate limits per route class, per client IP and per account
+	readLimit := middleware.RateLimit("read", rateFromConfig(config.AppConfig.RateLimitRead, "300/1m"))
+	loginLimit := middleware.RateLimit("login", rateFromConfig(config.AppConfig.RateLimitLogin, "10/1m"))
+	uploadLimit := middleware.RateLimit("upload", rateFromConfig(config.AppConfig.RateLimitUpload, "30/1m"))
+	writeLimit := middleware.WriteRateLimit(rateFromConfig(config.AppConfig.RateLimitWrite, "60/1m"))
+
 	// Public routes - Changed from /api to /v1
 	v1 := r.Group("/v1")
+	public := v1.Group("", readLimit)
 	{
 		newsHandler := handlers.NewNewsHandler()
 		categoryHandler := handlers.NewCategoryHandler()
 		tagHandler := handlers.NewTagHandler()
+		authorHandler := handlers.NewAuthorHandler()
+		collectionHandler := handlers.NewCollectionHandler()
 
 		// News routes
-		v1.GET("/news", newsHandler.GetNews)
-		v1.GET("/news/featured", newsHandler.GetFeaturedNews)
-		v1.GET("/news/search", newsHandler.SearchNews)
-		v1.GET("/categories", categoryHandler.GetCategories)
-		v1.GET("/tags", tagHandler.GetTags)
+		public.GET("/news", newsHandler.GetNews)
+		public.GET("/news/featured", newsHandler.GetFeaturedNews)
+		public.GET("/news/search", newsHandler.SearchNews)
+		public.GET("/news/breaking", newsHandler.GetBreakingNews)
+		public.GET("/news/:slug/updates", newsHandler.GetLiveUpdates)
+		public.GET("/news/:slug/blocks", newsHandler.GetNewsContent)
+		public.GET("/categories", categoryHandler.GetCategories)
+		public.GET("/categories/tree", categoryHandler.GetCategoryTree)
+		public.GET("/tags", tagHandler.GetTags)
+		public.GET("/tags/suggest", tagHandler.SuggestTags)
+		public.GET("/tags/:slug", tagHandler.GetTagBySlug)
+		public.GET("/authors/:username", authorHandler.GetAuthor)
+		public.GET("/collections/:slug", collectionHandler.GetCollection)
 
 		// Xinxun integration endpoint
-		v1.GET("/xinxun/newest", newsHandler.GetNewestNews)
+		public.GET("/xinxun/newest", newsHandler.GetNewestNews)
 
 		// News detail by slug - Must be last to avoid route conflicts
 		// Changed from /news/:slug to /:slug
-		v1.GET("/:slug", newsHandler.GetNewsBySlug)
+		public.GET("/:slug", newsHandler.GetNewsBySlug)
 	}
 
 	// Publisher routes (public) - Changed from /api to /v1
-	v1.POST("/publisher/login", handlers.NewPublisherHandler().Login)
+	v1.POST("/publisher/login", loginLimit, handlers.NewPublisherHandler().Login)
 
 	// Admin routes (protected) - Changed from /api/admin to /v1/admin
 	admin := v1.Group("/admin")
@@ -85,61 +116,158 @@ func SetupRoutes() *gin.Engine {
 		newsHandler := handlers.NewNewsHandler()
 		adminHandler := handlers.NewAdminHandler()
 		userHandler := handlers.NewUserHandler()
+		balanceHandler := handlers.NewBalanceHandler()
 
-		admin.POST("/login", authHandler.Login)
-		admin.POST("/upload", middleware.AuthMiddleware(), handlers.UploadImage)
+		admin.POST("/login", loginLimit, authHandler.Login)
+		admin.POST("/login/2fa", loginLimit, authHandler.LoginTwoFactor)
+		admin.POST("/password/forgot", loginLimit, authHandler.ForgotPassword)
+		admin.POST("/password/reset", loginLimit, authHandler.ResetPassword)
+		admin.POST("/email/verify", loginLimit, authHandler.VerifyEmail)
+		admin.POST("/email/verification", middleware.AuthMiddleware(), loginLimit, authHandler.ResendVerificationEmail)
+		admin.POST("/upload", middleware.AuthMiddleware(), middleware.RequireAccountSetup(), uploadLimit, handlers.UploadImage)
 
 		// User profile management
 		adminProfile := admin.Group("/profile")
-		adminProfile.Use(middleware.AuthMiddleware())
+		adminProfile.Use(middleware.AuthMiddleware(), writeLimit)
 		{
 			adminProfile.GET("", userHandler.GetProfile)
 			adminProfile.PUT("", userHandler.UpdateProfile)
 		}
 
+		// Two-factor enrollment. No RequireAccountSetup so that accounts
+		// required to enroll can reach it.
+		twoFactorHandler := handlers.NewTwoFactorHandler()
+		adminTwoFactor := admin.Group("/2fa")
+		adminTwoFactor.Use(middleware.AuthMiddleware(), loginLimit)
+		{
+			adminTwoFactor.GET("", twoFactorHandler.GetStatus)
+			adminTwoFactor.POST("/setup", twoFactorHandler.Setup)
+			adminTwoFactor.POST("/enable", twoFactorHandler.Enable)
+			adminTwoFactor.POST("/disable", twoFactorHandler.Disable)
+			adminTwoFactor.POST("/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
+		}
+
+		// Runtime settings (admin only)
+		settingHandler := handlers.NewSettingHandler()
+		adminSettings := admin.Group("/settings")
+		adminSettings.Use(middleware.AuthMiddleware(), middleware.RequireAccountSetup(), writeLimit)
+		{
+			adminSettings.GET("", settingHandler.GetSettings)
+			adminSettings.PUT("", settingHandler.UpdateSettings)
+		}
+
+		// Audit log (admin only)
+		auditLogHandler := handlers.NewAuditLogHandler()
+		admin.GET("/audit-logs", middleware.AuthMiddleware(), middleware.RequireAccountSetup(), readLimit, auditLogHandler.GetAuditLogs)
+
+		// Reward policies and view-milestone bonuses (admin only)
+		rewardHandler := handlers.NewRewardHandler()
+		adminRewards := admin.Group("/rewards")
+		adminRewards.Use(middleware.AuthMiddleware(), middleware.RequireAccountSetup(), writeLimit)
+		{
+			adminRewards.GET("/policies", rewardHandler.GetPolicies)
+			adminRewards.POST("/policies", rewardHandler.CreatePolicy)
+			adminRewards.PUT("/policies/:id", rewardHandler.UpdatePolicy)
+			adminRewards.DELETE("/policies/:id", rewardHandler.DeletePolicy)
+			adminRewards.GET("/milestones", rewardHandler.GetMilestones)
+			adminRewards.POST("/milestones", rewardHandler.CreateMilestone)
+			adminRewards.PUT("/milestones/:id", rewardHandler.UpdateMilestone)
+			adminRewards.DELETE("/milestones/:id", rewardHandler.DeleteMilestone)
+		}
+
 		// Publisher management (admin only)
 		adminPublishers := admin.Group("/publishers")
-		adminPublishers.Use(middleware.AuthMiddleware())
+		adminPublishers.Use(middleware.AuthMiddleware(), middleware.RequireAccountSetup(), writeLimit)
 		{
 			adminPublishers.GET("", userHandler.GetAllPublishers)
 			adminPublishers.GET("/:id", userHandler.GetPublisher)
 			adminPublishers.PUT("/:id", userHandler.UpdatePublisher)
 			adminPublishers.DELETE("/:id", userHandler.DeletePublisher)
+			adminPublishers.GET("/:id/ledger", balanceHandler.GetLedger)
+			adminPublishers.POST("/:id/balance-adjustments", balanceHandler.AdjustBalance)
+		}
+
+		// Admin & editor account management (admin only)
+		adminUsers := admin.Group("/users")
+		adminUsers.Use(middleware.AuthMiddleware(), middleware.RequireAccountSetup(), writeLimit)
+		{
+			adminUsers.GET("", userHandler.GetStaffUsers)
+			adminUsers.POST("", userHandler.CreateStaffUser)
+			adminUsers.GET("/:id", userHandler.GetStaffUser)
+			adminUsers.PUT("/:id", userHandler.UpdateStaffUser)
+			adminUsers.POST("/:id/disable", userHandler.DisableStaffUser)
+			adminUsers.POST("/:id/enable", userHandler.EnableStaffUser)
+			adminUsers.DELETE("/:id", userHandler.DeleteStaffUser)
 		}
 
 		// Category management (admin only)
 		categoryHandler := handlers.NewCategoryHandler()
 		adminCategories := admin.Group("/categories")
-		adminCategories.Use(middleware.AuthMiddleware())
+		adminCategories.Use(middleware.AuthMiddleware(), middleware.RequireAccountSetup(), writeLimit)
 		{
 			adminCategories.GET("", categoryHandler.GetCategories)
+			adminCategories.GET("/tree", categoryHandler.GetCategoryTree)
 			adminCategories.POST("", categoryHandler.CreateCategory)
+			adminCategories.PUT("/order", categoryHandler.ReorderCategories)
+			adminCategories.POST("/bulk-delete", categoryHandler.BulkDeleteCategories)
 			adminCategories.PUT("/:id", categoryHandler.UpdateCategory)
 			adminCategories.DELETE("/:id", categoryHandler.DeleteCategory)
 		}
 
 		adminNews := admin.Group("/news")
-		adminNews.Use(middleware.AuthMiddleware())
+		adminNews.Use(middleware.AuthMiddleware(), middleware.RequireAccountSetup(), writeLimit)
 		{
 			adminNews.GET("", newsHandler.GetNews) // Admin can see all statuses
 			adminNews.POST("", newsHandler.CreateNews)
+			adminNews.GET("/:id", newsHandler.GetNewsByID)
 			adminNews.PUT("/:id", newsHandler.UpdateNews)
 			adminNews.DELETE("/:id", newsHandler.DeleteNews)
 			adminNews.POST("/:id/approve", adminHandler.ApproveNews)
 			adminNews.POST("/:id/reject", adminHandler.RejectNews)
+			adminNews.POST("/bulk", adminHandler.BulkNews)
+			adminNews.POST("/:id/unpublish", adminHandler.UnpublishNews)
+			adminNews.POST("/:id/archive", adminHandler.ArchiveNews)
+			adminNews.POST("/:id/republish", adminHandler.RepublishNews)
+			adminNews.PUT("/:id/curation", adminHandler.UpdateCuration)
+			adminNews.PUT("/:id/breaking", adminHandler.UpdateBreaking)
+			adminNews.PUT("/:id/type", adminHandler.UpdateNewsType)
+			adminNews.GET("/:id/updates", adminHandler.GetLiveUpdates)
+			adminNews.POST("/:id/updates", adminHandler.CreateLiveUpdate)
+			adminNews.PUT("/:id/updates/:update_id", adminHandler.UpdateLiveUpdate)
+			adminNews.DELETE("/:id/updates/:update_id", adminHandler.DeleteLiveUpdate)
+			adminNews.GET("/trash", adminHandler.GetTrash)
+			adminNews.POST("/trash/:id/restore", adminHandler.RestoreNews)
+			adminNews.DELETE("/trash/:id", adminHandler.PurgeNews)
 			adminNews.GET("/pending", adminHandler.GetPendingNews)
 			adminNews.GET("/pending/revisions", adminHandler.GetPendingRevisions)
 			adminNews.GET("/pending/counts", adminHandler.GetPendingCounts)
 			adminNews.GET("/statistics", adminHandler.GetStatistics)
 		}
 
+		// Curated collections (admin & editor)
+		collectionHandler := handlers.NewCollectionHandler()
+		adminCollections := admin.Group("/collections")
+		adminCollections.Use(middleware.AuthMiddleware(), middleware.RequireAccountSetup(), writeLimit)
+		{
+			adminCollections.GET("", collectionHandler.GetCollections)
+			adminCollections.POST("", collectionHandler.CreateCollection)
+			adminCollections.GET("/:id", collectionHandler.GetCollectionByID)
+			adminCollections.PUT("/:id", collectionHandler.UpdateCollection)
+			adminCollections.PUT("/:id/items", collectionHandler.SetCollectionItems)
+			adminCollections.DELETE("/:id", collectionHandler.DeleteCollection)
+		}
+
 		// Tag management (admin only)
 		tagHandler := handlers.NewTagHandler()
 		adminTags := admin.Group("/tags")
-		adminTags.Use(middleware.AuthMiddleware())
+		adminTags.Use(middleware.AuthMiddleware(), middleware.RequireAccountSetup(), writeLimit)
 		{
 			adminTags.GET("", tagHandler.GetTags)
 			adminTags.POST("", tagHandler.CreateTag)
+			adminTags.POST("/suggest", tagHandler.SuggestTagsForContent)
+			adminTags.PUT("/order", tagHandler.ReorderTags)
+			adminTags.POST("/bulk-delete", tagHandler.BulkDeleteTags)
+			adminTags.POST("/:id/merge", tagHandler.MergeTags)
 			adminTags.PUT("/:id", tagHandler.UpdateTag)
 			adminTags.DELETE("/:id", tagHandler.DeleteTag)
 		}
@@ -147,14 +275,31 @@ func SetupRoutes() *gin.Engine {
 
 	// Publisher routes (protected) - Changed from /api/publisher to /v1/publisher
 	publisher := v1.Group("/publisher")
-	publisher.Use(middleware.AuthMiddleware())
+	publisher.Use(middleware.AuthMiddleware(), writeLimit)
 	{
 		newsHandler := handlers.NewNewsHandler()
 		publisherHandler := handlers.NewPublisherHandler()
+		balanceHandler := handlers.NewBalanceHandler()
+		publisher.GET("/news/:id", newsHandler.GetNewsByID)
 		publisher.POST("/news", newsHandler.CreateNews)
 		publisher.PUT("/news/:id", newsHandler.UpdateNews)
 		publisher.GET("/statistics", publisherHandler.GetPublisherStatistics)
+		publisher.GET("/earnings", balanceHandler.GetEarnings)
+		publisher.GET("/quota", publisherHandler.GetQuota)
+		publisher.GET("/ledger", balanceHandler.GetLedger)
+		publisher.POST("/tags/suggest", handlers.NewTagHandler().SuggestTagsForContent)
 	}
 
 	return r
 }
+
+// rateFromConfig parses a configured rate, falling back to the default when
+// the value is invalid
+func rateFromConfig(value, fallback string) ratelimit.Rate {
+	rate, err := ratelimit.ParseRate(value)
+	if err != nil {
+		log.Printf("Warning: %v, using %s", err, fallback)
+		rate, _ = ratelimit.ParseRate(fallback)
+	}
+	return rate
+}
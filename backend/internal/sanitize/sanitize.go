@@ -0,0 +1,358 @@
+// Package sanitize cleans article HTML with an allow-list Policy. Markup
+// outside the policy is removed: unknown tags are unwrapped (their text is
+// kept), dangerous ones such as <script> are dropped with their content, and
+// attributes, link targets, image sources and embeds are checked one by one.
+package sanitize
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Policy lists the markup kept in article content
+type Policy struct {
+	// Tags maps each allowed tag to its allowed attributes. The global
+	// "class" and "style" attributes are filtered further (see GlobalAttrs).
+	Tags map[string][]string
+	// GlobalAttrs are allowed on every tag in Tags
+	GlobalAttrs []string
+	// ImageHosts are the hosts <img> sources may point to. An entry
+	// starting with "*." also matches every subdomain.
+	ImageHosts []string
+	// EmbedHosts are the hosts <iframe> sources may point to (https only)
+	EmbedHosts []string
+	// LinkRel is set as the rel attribute of every link
+	LinkRel string
+}
+
+// DefaultPolicy returns the policy matching the markup produced by the
+// article editor: headings, paragraphs, lists, quotes, basic formatting,
+// links, images and video embeds. It allows no image hosts.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		Tags: map[string][]string{
+			"p": nil, "br": nil, "hr": nil, "span": nil,
+			"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+			"strong": nil, "b": nil, "em": nil, "i": nil, "u": nil, "s": nil, "strike": nil,
+			"sub": nil, "sup": nil, "blockquote": nil, "pre": nil, "code": nil,
+			"ol": nil, "ul": nil, "li": nil,
+			"figure": nil, "figcaption": nil,
+			"table": nil, "thead": nil, "tbody": nil, "tr": nil,
+			"th": {"colspan", "rowspan"}, "td": {"colspan", "rowspan"},
+			"a":      {"href", "title", "target"},
+			"img":    {"src", "alt", "title", "width", "height"},
+			"iframe": {"src", "width", "height", "allowfullscreen", "frameborder"},
+		},
+		GlobalAttrs: []string{"class", "style"},
+		EmbedHosts:  []string{"www.youtube.com", "www.youtube-nocookie.com", "player.vimeo.com"},
+		LinkRel:     "nofollow noopener",
+	}
+}
+
+var (
+	mu     sync.RWMutex
+	active = DefaultPolicy()
+)
+
+// SetPolicy replaces the policy used by Content
+func SetPolicy(p *Policy) {
+	mu.Lock()
+	defer mu.Unlock()
+	active = p
+}
+
+// Content sanitizes article HTML with the active policy
+func Content(content string) string {
+	mu.RLock()
+	p := active
+	mu.RUnlock()
+	return p.Sanitize(content)
+}
+
+// ImageAllowed reports whether the active policy accepts an image source
+func ImageAllowed(raw string) bool {
+	mu.RLock()
+	p := active
+	mu.RUnlock()
+	return p.allowedSource(raw, p.ImageHosts, false)
+}
+
+// EmbedAllowed reports whether the active policy accepts an embed source
+func EmbedAllowed(raw string) bool {
+	mu.RLock()
+	p := active
+	mu.RUnlock()
+	return p.allowedSource(raw, p.EmbedHosts, true)
+}
+
+// droppedTags are removed together with everything inside them
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true,
+	"object": true, "embed": true, "applet": true, "frame": true, "frameset": true,
+	"form": true, "input": true, "button": true, "textarea": true, "select": true,
+	"svg": true, "math": true, "head": true, "title": true, "meta": true, "link": true, "base": true,
+}
+
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true}
+
+var (
+	// Classes written by the editor (alignment, indentation, fonts, sizes, video)
+	classPattern = regexp.MustCompile(`^ql-[a-z0-9-]+$`)
+	// Colors and alignment set by the editor toolbar
+	styleProperties = map[string]bool{"color": true, "background-color": true, "text-align": true}
+	styleValue      = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|rgba?\([0-9.,%\s]+\)|[a-zA-Z]+)$`)
+	sizeValue       = regexp.MustCompile(`^[0-9]{1,4}%?$`)
+)
+
+// Sanitize returns the content with everything outside the policy removed
+func (p *Policy) Sanitize(content string) string {
+	if strings.TrimSpace(content) == "" {
+		return content
+	}
+
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(content), context)
+	if err != nil {
+		// The parser only fails on reader errors; keep nothing rather
+		// than unchecked markup
+		return ""
+	}
+
+	var out strings.Builder
+	for _, node := range nodes {
+		p.render(&out, node)
+	}
+	return out.String()
+}
+
+func (p *Policy) render(out *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		out.WriteString(escapeText(n.Data))
+	case html.ElementNode:
+		p.renderElement(out, n)
+	case html.DocumentNode:
+		p.renderChildren(out, n)
+	}
+	// Comments and doctypes are dropped
+}
+
+func (p *Policy) renderChildren(out *strings.Builder, n *html.Node) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		p.render(out, child)
+	}
+}
+
+func (p *Policy) renderElement(out *strings.Builder, n *html.Node) {
+	tag := strings.ToLower(n.Data)
+	if droppedTags[tag] {
+		return
+	}
+	allowed, ok := p.Tags[tag]
+	if !ok {
+		p.renderChildren(out, n)
+		return
+	}
+
+	attrs, ok := p.filterAttrs(tag, allowed, n.Attr)
+	if !ok {
+		// Images and embeds from unknown sources are removed entirely
+		return
+	}
+
+	out.WriteString("<" + tag)
+	for _, attr := range attrs {
+		out.WriteString(" " + attr.Key + `="` + escapeAttr(attr.Val) + `"`)
+	}
+	out.WriteString(">")
+	if voidTags[tag] {
+		return
+	}
+	if tag != "iframe" {
+		p.renderChildren(out, n)
+	}
+	out.WriteString("</" + tag + ">")
+}
+
+// filterAttrs keeps the allowed attributes of one element. ok is false when
+// the element must be dropped because of its source.
+func (p *Policy) filterAttrs(tag string, allowed []string, attrs []html.Attribute) (result []html.Attribute, ok bool) {
+	for _, attr := range attrs {
+		key := strings.ToLower(attr.Key)
+		if attr.Namespace != "" || !contains(allowed, key) && !contains(p.GlobalAttrs, key) {
+			continue
+		}
+
+		value := attr.Val
+		switch key {
+		case "class":
+			value = filterClasses(value)
+		case "style":
+			value = filterStyle(value)
+		case "width", "height", "colspan", "rowspan", "frameborder":
+			if !sizeValue.MatchString(strings.TrimSpace(value)) {
+				value = ""
+			}
+		case "target":
+			if value != "_blank" {
+				value = ""
+			}
+		case "allowfullscreen":
+			value = "true"
+		case "href":
+			if !safeLink(value) {
+				value = ""
+			}
+		case "src":
+			switch tag {
+			case "img":
+				if !p.allowedSource(value, p.ImageHosts, false) {
+					return nil, false
+				}
+			case "iframe":
+				if !p.allowedSource(value, p.EmbedHosts, true) {
+					return nil, false
+				}
+			default:
+				value = ""
+			}
+		}
+		if value == "" {
+			continue
+		}
+		result = append(result, html.Attribute{Key: key, Val: value})
+	}
+
+	switch tag {
+	case "img", "iframe":
+		if !hasAttr(result, "src") {
+			return nil, false
+		}
+	case "a":
+		if p.LinkRel != "" {
+			result = append(result, html.Attribute{Key: "rel", Val: p.LinkRel})
+		}
+	}
+	return result, true
+}
+
+// safeLink accepts http, https and mailto links and relative URLs
+func safeLink(raw string) bool {
+	u, ok := parseURL(raw)
+	if !ok {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+// allowedSource reports whether raw is an absolute URL on one of hosts
+func (p *Policy) allowedSource(raw string, hosts []string, httpsOnly bool) bool {
+	u, ok := parseURL(raw)
+	if !ok {
+		return false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "https" && (httpsOnly || scheme != "http") {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range hosts {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == "" {
+			continue
+		}
+		if strings.HasPrefix(allowed, "*.") {
+			if strings.HasSuffix(host, allowed[1:]) || host == allowed[2:] {
+				return true
+			}
+		} else if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// parseURL parses a URL attribute. Values with control characters are
+// rejected because browsers skip them, which can hide a "javascript:" scheme.
+func parseURL(raw string) (*url.URL, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, false
+	}
+	for _, r := range raw {
+		if r < 0x20 || r == 0x7f {
+			return nil, false
+		}
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, false
+	}
+	return u, true
+}
+
+func filterClasses(value string) string {
+	var kept []string
+	for _, class := range strings.Fields(value) {
+		if classPattern.MatchString(class) {
+			kept = append(kept, class)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+func filterStyle(value string) string {
+	var kept []string
+	for _, declaration := range strings.Split(value, ";") {
+		name, val, found := strings.Cut(declaration, ":")
+		if !found {
+			continue
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		val = strings.TrimSpace(val)
+		if styleProperties[name] && styleValue.MatchString(val) {
+			kept = append(kept, name+": "+val)
+		}
+	}
+	return strings.Join(kept, "; ")
+}
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func escapeAttr(s string) string {
+	return attrEscaper.Replace(s)
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\u00a0", "&nbsp;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", `"`, "&quot;", "<", "&lt;", ">", "&gt;")
+)
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAttr(attrs []html.Attribute, key string) bool {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package sanitize
+
+import "testing"
+
+func testPolicy() *Policy {
+	p := DefaultPolicy()
+	p.ImageHosts = []string{"cdn.example.com", "*.images.example.org"}
+	return p
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		// Tags
+		{"allowed markup kept", `<p>Halo <strong>dunia</strong></p>`, `<p>Halo <strong>dunia</strong></p>`},
+		{"unknown tag unwrapped", `<p><marquee>teks</marquee></p>`, `<p>teks</p>`},
+		{"script dropped with content", `<p>a</p><script>alert(1)</script><p>b</p>`, `<p>a</p><p>b</p>`},
+		{"style dropped with content", `<style>p{color:red}</style><p>a</p>`, `<p>a</p>`},
+		{"form dropped with content", `<form><input name="x"><button>kirim</button></form>`, ``},
+		{"svg dropped with content", `<svg><script>alert(1)</script></svg>teks`, `teks`},
+		{"comment dropped", `<p>a<!-- rahasia --></p>`, `<p>a</p>`},
+		{"text escaped", `<p>1 &lt; 2 &amp; 3</p>`, `<p>1 &lt; 2 &amp; 3</p>`},
+
+		// Attributes
+		{"event handler removed", `<p onclick="alert(1)">a</p>`, `<p>a</p>`},
+		{"attribute of another tag removed", `<p href="https://example.com">a</p>`, `<p>a</p>`},
+		{"editor class kept", `<p class="ql-align-center evil">a</p>`, `<p class="ql-align-center">a</p>`},
+		{"style filtered", `<p style="color: red; position: fixed; background-image: url(x)">a</p>`, `<p style="color: red">a</p>`},
+		{"invalid size removed", `<table><tr><td colspan="2" rowspan="x">a</td></tr></table>`, `<table><tbody><tr><td colspan="2">a</td></tr></tbody></table>`},
+		{"target other than _blank removed", `<a href="/a" target="_top">a</a>`, `<a href="/a" rel="nofollow noopener">a</a>`},
+		{"quotes in attribute escaped", `<a href="/a" title='x"y'>a</a>`, `<a href="/a" title="x&quot;y" rel="nofollow noopener">a</a>`},
+
+		// Links
+		{"https link gets rel", `<a href="https://example.com">a</a>`, `<a href="https://example.com" rel="nofollow noopener">a</a>`},
+		{"mailto link kept", `<a href="mailto:redaksi@example.com">a</a>`, `<a href="mailto:redaksi@example.com" rel="nofollow noopener">a</a>`},
+		{"existing rel replaced", `<a href="/a" rel="opener">a</a>`, `<a href="/a" rel="nofollow noopener">a</a>`},
+		{"javascript href removed", `<a href="javascript:alert(1)">a</a>`, `<a rel="nofollow noopener">a</a>`},
+		{"uppercase javascript href removed", `<a href="JaVaScRiPt:alert(1)">a</a>`, `<a rel="nofollow noopener">a</a>`},
+		{"javascript href hidden by tab removed", "<a href=\"java\tscript:alert(1)\">a</a>", `<a rel="nofollow noopener">a</a>`},
+		{"data href removed", `<a href="data:text/html;base64,PHNjcmlwdD4=">a</a>`, `<a rel="nofollow noopener">a</a>`},
+		{"vbscript href removed", `<a href="vbscript:msgbox(1)">a</a>`, `<a rel="nofollow noopener">a</a>`},
+
+		// Images
+		{"image from allowed host", `<img src="https://cdn.example.com/a.jpg" alt="a">`, `<img src="https://cdn.example.com/a.jpg" alt="a">`},
+		{"image from allowed subdomain", `<img src="https://x.images.example.org/a.jpg">`, `<img src="https://x.images.example.org/a.jpg">`},
+		{"image from wildcard base host", `<img src="https://images.example.org/a.jpg">`, `<img src="https://images.example.org/a.jpg">`},
+		{"image from other host dropped", `<p><img src="https://evil.com/a.jpg">a</p>`, `<p>a</p>`},
+		{"image from lookalike host dropped", `<img src="https://cdn.example.com.evil.com/a.jpg">`, ``},
+		{"image with suffix lookalike dropped", `<img src="https://evilimages.example.org/a.jpg">`, ``},
+		{"data image dropped", `<img src="data:image/png;base64,iVBORw0KGgo=">`, ``},
+		{"javascript image dropped", `<img src="javascript:alert(1)">`, ``},
+		{"relative image dropped", `<img src="/uploads/a.jpg">`, ``},
+		{"image without src dropped", `<img alt="a">`, ``},
+
+		// Embeds
+		{"youtube embed kept", `<iframe src="https://www.youtube.com/embed/abc" allowfullscreen></iframe>`, `<iframe src="https://www.youtube.com/embed/abc" allowfullscreen="true"></iframe>`},
+		{"vimeo embed kept", `<iframe src="https://player.vimeo.com/video/1" width="640"></iframe>`, `<iframe src="https://player.vimeo.com/video/1" width="640"></iframe>`},
+		{"embed over http dropped", `<iframe src="http://www.youtube.com/embed/abc"></iframe>`, ``},
+		{"embed from other provider dropped", `<iframe src="https://evil.com/embed"></iframe>`, ``},
+		{"javascript embed dropped", `<iframe src="javascript:alert(1)"></iframe>`, ``},
+		{"data embed dropped", `<iframe src="data:text/html,<script>alert(1)</script>"></iframe>`, ``},
+		{"object and embed dropped", `<object data="x.swf"><embed src="x.swf"></object>`, ``},
+	}
+
+	p := testPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Sanitize(tt.in); got != tt.want {
+				t.Errorf("Sanitize(%q)\n got  %q\n want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultPolicyAllowsNoImageHosts(t *testing.T) {
+	if got := DefaultPolicy().Sanitize(`<img src="https://cdn.example.com/a.jpg">`); got != "" {
+		t.Errorf("got %q, want the image removed", got)
+	}
+}
+
+func TestLinkRelFromPolicy(t *testing.T) {
+	p := testPolicy()
+	p.LinkRel = "nofollow ugc"
+	want := `<a href="https://example.com" rel="nofollow ugc">a</a>`
+	if got := p.Sanitize(`<a href="https://example.com">a</a>`); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSourceChecks(t *testing.T) {
+	old := active
+	defer SetPolicy(old)
+	SetPolicy(testPolicy())
+
+	images := []struct {
+		src  string
+		want bool
+	}{
+		{"https://cdn.example.com/a.jpg", true},
+		{"http://cdn.example.com/a.jpg", true},
+		{"https://CDN.Example.com/a.jpg", true},
+		{"https://evil.com/a.jpg", false},
+		{"//cdn.example.com/a.jpg", false},
+		{"data:image/png;base64,AAAA", false},
+		{"", false},
+	}
+	for _, tt := range images {
+		if got := ImageAllowed(tt.src); got != tt.want {
+			t.Errorf("ImageAllowed(%q) = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+
+	embeds := []struct {
+		src  string
+		want bool
+	}{
+		{"https://www.youtube.com/embed/abc", true},
+		{"https://www.youtube-nocookie.com/embed/abc", true},
+		{"https://player.vimeo.com/video/1", true},
+		{"http://player.vimeo.com/video/1", false},
+		{"https://youtube.com.evil.com/embed/abc", false},
+		{"javascript:alert(1)", false},
+	}
+	for _, tt := range embeds {
+		if got := EmbedAllowed(tt.src); got != tt.want {
+			t.Errorf("EmbedAllowed(%q) = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package services
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	"xinxun-news/internal/config"
@@ -19,14 +22,88 @@ func CheckPasswordHash(password, hash string) bool {
 	return err == nil
 }
 
-func GenerateToken(userID uint, userType string) (string, error) {
+// TokenFlags restrict a session until the account setup is completed
+type TokenFlags struct {
+	MustChangePassword bool // Only the profile endpoint until the password is changed
+	MustSetupTwoFactor bool // Only 2FA enrollment until two-factor auth is enabled
+}
+
+// GenerateToken issues the session JWT. tokenVersion is the user's
+// TokenVersion, so the token stops working once the password changes.
+func GenerateToken(userID uint, userType string, tokenVersion uint, flags TokenFlags) (string, error) {
 	claims := jwt.MapClaims{
-		"user_id":   userID,
-		"user_type": userType,
-		"exp":       time.Now().Add(time.Hour * 24).Unix(),
+		"user_id":       userID,
+		"user_type":     userType,
+		"token_version": tokenVersion,
+		"exp":           time.Now().Add(time.Hour * 24).Unix(),
+	}
+	if flags.MustChangePassword {
+		claims["must_change_password"] = true
+	}
+	if flags.MustSetupTwoFactor {
+		claims["must_setup_two_factor"] = true
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(config.AppConfig.JWTSecret))
 }
 
+// GenerateOpaqueToken returns a random token to send to the user and the
+// hash to store in the database
+func GenerateOpaqueToken() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(raw)
+	return token, HashOpaqueToken(token), nil
+}
+
+// HashOpaqueToken hashes a token from GenerateOpaqueToken for lookup
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// TwoFactorChallengeTTL is how long a user has to enter the 2FA code after
+// the password step
+const TwoFactorChallengeTTL = 5 * time.Minute
+
+// GenerateChallengeToken issues the short-lived token returned after a
+// correct password when the account has two-factor auth enabled. It is not
+// accepted by AuthMiddleware.
+func GenerateChallengeToken(userID uint) (string, error) {
+	claims := jwt.MapClaims{
+		"user_id": userID,
+		"purpose": "2fa_challenge",
+		"exp":     time.Now().Add(TwoFactorChallengeTTL).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(config.AppConfig.JWTSecret))
+}
+
+// ParseChallengeToken validates a token from GenerateChallengeToken and
+// returns its user ID
+func ParseChallengeToken(tokenString string) (uint, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(config.AppConfig.JWTSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return 0, jwt.ErrTokenInvalidClaims
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != "2fa_challenge" {
+		return 0, jwt.ErrTokenInvalidClaims
+	}
+
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return 0, jwt.ErrTokenInvalidClaims
+	}
+	return uint(userID), nil
+}
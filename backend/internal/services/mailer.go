@@ -0,0 +1,122 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"xinxun-news/internal/config"
+)
+
+// MailMessage is a plain-text email
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers emails. Use SMTPMailer in production and FileMailer or
+// LogMailer in development and tests.
+type Mailer interface {
+	Send(msg MailMessage) error
+}
+
+var mailer Mailer = LogMailer{}
+
+// InitMailer selects the mailer from MAIL_DRIVER
+func InitMailer() {
+	cfg := config.AppConfig
+	switch cfg.MailDriver {
+	case "smtp":
+		if cfg.SMTPHost == "" {
+			log.Println("[InitMailer] SMTP_HOST not set, falling back to log mailer")
+			mailer = LogMailer{}
+			return
+		}
+		mailer = &SMTPMailer{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.MailFrom,
+		}
+	case "file":
+		mailer = &FileMailer{Path: cfg.MailFilePath}
+	default:
+		mailer = LogMailer{}
+	}
+	log.Printf("[InitMailer] Mail driver: %s", cfg.MailDriver)
+}
+
+// SetMailer replaces the active mailer, e.g. with a stub in tests
+func SetMailer(m Mailer) {
+	mailer = m
+}
+
+// SendMail delivers msg with the active mailer
+func SendMail(msg MailMessage) error {
+	return mailer.Send(msg)
+}
+
+// SMTPMailer sends mail through an SMTP server using PLAIN auth
+type SMTPMailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (m *SMTPMailer) Send(msg MailMessage) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	headers := []string{
+		"From: " + m.From,
+		"To: " + msg.To,
+		"Subject: " + msg.Subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}
+	body := strings.Join(headers, "\r\n") + "\r\n\r\n" + msg.Body
+
+	if err := smtp.SendMail(m.Host+":"+m.Port, auth, m.From, []string{msg.To}, []byte(body)); err != nil {
+		return fmt.Errorf("SMTP send failed: %w", err)
+	}
+	return nil
+}
+
+// FileMailer appends every message to a file instead of sending it
+type FileMailer struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func (m *FileMailer) Send(msg MailMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "==== %s\nTo: %s\nSubject: %s\n\n%s\n\n",
+		time.Now().Format(time.RFC3339), msg.To, msg.Subject, msg.Body)
+	return err
+}
+
+// LogMailer writes messages to the application log
+type LogMailer struct{}
+
+func (LogMailer) Send(msg MailMessage) error {
+	log.Printf("[Mail] To: %s, Subject: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
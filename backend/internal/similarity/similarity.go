@@ -0,0 +1,142 @@
+// Package similarity estimates how much two texts overlap using word
+// shingles and MinHash signatures, so new submissions can be compared with
+// stored articles without keeping the full texts in memory. Signature bands
+// narrow the comparison down to likely matches.
+package similarity
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// ShingleSize is the number of consecutive words in a shingle
+	ShingleSize = 5
+	// SignatureSize is the number of MinHash values per signature
+	SignatureSize = 64
+	// BandRows is the number of MinHash values in one band
+	BandRows = 2
+	// BandCount is the number of bands a signature is split into
+	BandCount = SignatureSize / BandRows
+)
+
+// seeds derive the SignatureSize hash functions from one base hash
+var seeds = func() [SignatureSize]uint64 {
+	var s [SignatureSize]uint64
+	state := uint64(0x9e3779b97f4a7c15)
+	for i := range s {
+		state = splitmix64(state)
+		s[i] = state
+	}
+	return s
+}()
+
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// normalizeWords lowercases text and splits it into words, dropping
+// punctuation so formatting changes do not hide a copy
+func normalizeWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// shingles returns the hashes of every ShingleSize-word window of text.
+// Texts shorter than one window are a single shingle.
+func shingles(text string) []uint64 {
+	words := normalizeWords(text)
+	if len(words) == 0 {
+		return nil
+	}
+
+	size := ShingleSize
+	if len(words) < size {
+		size = len(words)
+	}
+
+	seen := map[uint64]bool{}
+	result := make([]uint64, 0, len(words)-size+1)
+	for i := 0; i+size <= len(words); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:i+size], " ")))
+		sum := h.Sum64()
+		if !seen[sum] {
+			seen[sum] = true
+			result = append(result, sum)
+		}
+	}
+	return result
+}
+
+// Signature returns the encoded MinHash signature of a plain text, or an
+// empty string when the text has no words
+func Signature(text string) string {
+	hashes := shingles(text)
+	if len(hashes) == 0 {
+		return ""
+	}
+
+	raw := make([]byte, SignatureSize*4)
+	for i, seed := range seeds {
+		min := ^uint32(0)
+		for _, h := range hashes {
+			if v := uint32(splitmix64(h ^ seed)); v < min {
+				min = v
+			}
+		}
+		binary.BigEndian.PutUint32(raw[i*4:], min)
+	}
+	return base64.RawStdEncoding.EncodeToString(raw)
+}
+
+// Compare estimates the Jaccard similarity (0 to 1) of the texts behind two
+// signatures. Invalid or empty signatures compare as 0.
+func Compare(a, b string) float64 {
+	rawA, okA := decode(a)
+	rawB, okB := decode(b)
+	if !okA || !okB {
+		return 0
+	}
+
+	equal := 0
+	for i := 0; i < SignatureSize*4; i += 4 {
+		if binary.BigEndian.Uint32(rawA[i:]) == binary.BigEndian.Uint32(rawB[i:]) {
+			equal++
+		}
+	}
+	return float64(equal) / SignatureSize
+}
+
+// Bands splits a signature into BandCount values for locality sensitive
+// hashing, each packing the BandRows MinHash values of one band. Two texts
+// share at least one band value with a probability of about 95% at a
+// similarity of 0.3, and rarely when they are unrelated, so the bands can be
+// indexed to find candidates without comparing every stored signature.
+// Invalid signatures have no bands.
+func Bands(signature string) []uint64 {
+	raw, ok := decode(signature)
+	if !ok {
+		return nil
+	}
+	bands := make([]uint64, BandCount)
+	for i := range bands {
+		bands[i] = binary.BigEndian.Uint64(raw[i*BandRows*4:])
+	}
+	return bands
+}
+
+func decode(signature string) ([]byte, bool) {
+	raw, err := base64.RawStdEncoding.DecodeString(signature)
+	if err != nil || len(raw) != SignatureSize*4 {
+		return nil, false
+	}
+	return raw, true
+}
@@ -0,0 +1,88 @@
+// Package tagsuggest proposes existing tags for an article by looking for
+// the tag names, in every language, in its title and text.
+package tagsuggest
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"xinxun-news/internal/models"
+)
+
+// titleWeight is how much more a match in the title counts than one in
+// the text
+const titleWeight = 3
+
+// Suggestion is a tag found in an article. Score grows with the number of
+// matches.
+type Suggestion struct {
+	Tag   models.Tag
+	Score int
+}
+
+// Suggest returns up to limit tags whose name appears in the title or text,
+// best matches first
+func Suggest(tags []models.Tag, title, text string, limit int) []Suggestion {
+	titleWords := words(title)
+	textWords := words(text)
+
+	var suggestions []Suggestion
+	for _, tag := range tags {
+		best := 0
+		for _, name := range names(tag) {
+			phrase := words(name)
+			score := titleWeight*count(titleWords, phrase) + count(textWords, phrase)
+			if score > best {
+				best = score
+			}
+		}
+		if best > 0 {
+			suggestions = append(suggestions, Suggestion{Tag: tag, Score: best})
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+func names(tag models.Tag) []string {
+	result := []string{tag.Name}
+	for _, name := range tag.Names {
+		result = append(result, name)
+	}
+	return result
+}
+
+// words lowercases text and splits it into words, dropping punctuation
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// count returns how often phrase occurs in text as whole words
+func count(text, phrase []string) int {
+	if len(phrase) == 0 {
+		return 0
+	}
+	n := 0
+	for i := 0; i+len(phrase) <= len(text); i++ {
+		match := true
+		for j, word := range phrase {
+			if text[i+j] != word {
+				match = false
+				break
+			}
+		}
+		if match {
+			n++
+		}
+	}
+	return n
+}
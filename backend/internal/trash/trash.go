@@ -0,0 +1,137 @@
+// Package trash permanently removes soft-deleted articles, together with
+// the uploaded media no other article uses. Articles stay in the trash for
+// the number of days in the trash.retention_days setting.
+package trash
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+	"xinxun-news/internal/services"
+)
+
+// DefaultRetentionDays is used when the retention setting is not set
+const DefaultRetentionDays = 30
+
+// purgeBatchSize bounds the articles purged in one run
+const purgeBatchSize = 100
+
+// RetentionDays returns how long deleted articles are kept; 0 keeps them
+// until they are purged by hand
+func RetentionDays() int {
+	return repository.NewSettingRepository().GetInt(models.SettingTrashRetentionDays, DefaultRetentionDays)
+}
+
+// PurgeAt returns when an article deleted at deletedAt is purged, or nil
+// when the trash is never emptied automatically
+func PurgeAt(deletedAt time.Time) *time.Time {
+	days := RetentionDays()
+	if days <= 0 {
+		return nil
+	}
+	at := deletedAt.AddDate(0, 0, days)
+	return &at
+}
+
+// StartPurgeWorker empties expired trash every interval until the process
+// exits
+func StartPurgeWorker(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if purged, err := PurgeExpired(); err != nil {
+				log.Printf("[Trash] Purge run failed: %v", err)
+			} else if purged > 0 {
+				log.Printf("[Trash] Purged %d articles", purged)
+			}
+		}
+	}()
+}
+
+// PurgeExpired purges the articles deleted longer than the retention period
+// ago. It returns the number of articles purged. An article that cannot be
+// purged is logged and skipped so it does not hold up the others.
+func PurgeExpired() (int, error) {
+	days := RetentionDays()
+	if days <= 0 {
+		return 0, nil
+	}
+
+	expired, err := repository.NewNewsRepository().FindTrashedBefore(time.Now().AddDate(0, 0, -days), purgeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for i := range expired {
+		if err := Purge(&expired[i]); err != nil {
+			log.Printf("[Trash] Could not purge news %d: %v", expired[i].ID, err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// Purge permanently deletes an article, then removes the uploaded images it
+// or its live updates used unless another article still uses them. Media
+// that cannot be removed is logged and left behind.
+func Purge(news *models.News) error {
+	newsRepo := repository.NewNewsRepository()
+	liveContents, err := repository.NewLiveUpdateRepository().FindContents(news.ID)
+	if err != nil {
+		return err
+	}
+	if err := newsRepo.Purge(news.ID); err != nil {
+		return err
+	}
+
+	for _, url := range media(news, liveContents) {
+		count, err := newsRepo.CountMediaReferences(url, news.ID)
+		if err != nil {
+			log.Printf("[Trash] Could not check media %s of news %d: %v", url, news.ID, err)
+			continue
+		}
+		if count > 0 {
+			continue
+		}
+		if err := services.DeleteFromS3(uploadKey(url)); err != nil {
+			log.Printf("[Trash] Could not delete media %s of news %d: %v", url, news.ID, err)
+		}
+	}
+	return nil
+}
+
+// media returns the uploaded images of an article: its thumbnail and the
+// images in its content and live updates that live in the upload bucket
+func media(news *models.News, liveContents []string) []string {
+	candidates := append([]string{news.Thumbnail}, models.Images(news.Content)...)
+	for _, content := range liveContents {
+		candidates = append(candidates, models.Images(content)...)
+	}
+
+	seen := map[string]bool{}
+	var urls []string
+	for _, url := range candidates {
+		if url == "" || seen[url] || uploadKey(url) == "" {
+			continue
+		}
+		seen[url] = true
+		urls = append(urls, url)
+	}
+	return urls
+}
+
+// uploadKey returns the bucket key of an uploaded file URL, or an empty
+// string for URLs outside the upload bucket
+func uploadKey(url string) string {
+	prefix := services.GetS3URL("")
+	if prefix == "" || !strings.HasPrefix(url, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(url, prefix)
+}
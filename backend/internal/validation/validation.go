@@ -0,0 +1,220 @@
+// Package validation checks articles against the content quality rules
+// configured in settings and reports every violation at once.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // Thumbnail formats whose dimensions can be checked
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"xinxun-news/internal/models"
+	"xinxun-news/internal/repository"
+	"xinxun-news/internal/sanitize"
+
+	"golang.org/x/net/html"
+)
+
+// Default rules used until an admin changes them in settings
+const (
+	DefaultTitleMaxWords   = 100
+	DefaultExcerptMaxWords = 200
+)
+
+// Rules are the content quality rules. A limit of 0 disables the rule.
+type Rules struct {
+	TitleMaxWords      int      `json:"title_max_words"`
+	TitleMinChars      int      `json:"title_min_chars"`
+	TitleMaxChars      int      `json:"title_max_chars"`
+	ExcerptMaxWords    int      `json:"excerpt_max_words"`
+	ContentMinWords    int      `json:"content_min_words"`
+	ThumbnailMinWidth  int      `json:"thumbnail_min_width"`
+	ThumbnailMinHeight int      `json:"thumbnail_min_height"`
+	MaxLinks           int      `json:"max_links"`
+	MinTags            int      `json:"min_tags"`
+	BannedWords        []string `json:"banned_words"`
+}
+
+// LoadRules reads the rules from settings
+func LoadRules() Rules {
+	settingRepo := repository.NewSettingRepository()
+	return Rules{
+		TitleMaxWords:      settingRepo.GetInt(models.SettingContentTitleMaxWords, DefaultTitleMaxWords),
+		TitleMinChars:      settingRepo.GetInt(models.SettingContentTitleMinChars, 0),
+		TitleMaxChars:      settingRepo.GetInt(models.SettingContentTitleMaxChars, 0),
+		ExcerptMaxWords:    settingRepo.GetInt(models.SettingContentExcerptMaxWords, DefaultExcerptMaxWords),
+		ContentMinWords:    settingRepo.GetInt(models.SettingContentMinWords, 0),
+		ThumbnailMinWidth:  settingRepo.GetInt(models.SettingContentThumbnailMinWidth, 0),
+		ThumbnailMinHeight: settingRepo.GetInt(models.SettingContentThumbnailMinHeight, 0),
+		MaxLinks:           settingRepo.GetInt(models.SettingContentMaxLinks, 0),
+		MinTags:            settingRepo.GetInt(models.SettingContentMinTags, 0),
+		BannedWords:        settingRepo.GetList(models.SettingContentBannedWords),
+	}
+}
+
+// Article is the submitted state of an article
+type Article struct {
+	Title    string
+	Excerpt  string
+	Content  string // HTML
+	TagCount int
+	// Thumbnail is checked against the dimension rules. Leave it empty when
+	// the thumbnail did not change to skip downloading it again.
+	Thumbnail string
+}
+
+// Violation is one broken rule. Args are the values the message of the
+// rule is formatted with (the limit, the banned words found, ...).
+type Violation struct {
+	Field string
+	Rule  string
+	Args  []interface{}
+}
+
+// Validate returns every rule the article breaks, or nil
+func Validate(article Article, rules Rules) []Violation {
+	var violations []Violation
+	add := func(field, rule string, args ...interface{}) {
+		violations = append(violations, Violation{Field: field, Rule: rule, Args: args})
+	}
+
+	title := strings.TrimSpace(article.Title)
+	if rules.TitleMaxWords > 0 && len(strings.Fields(title)) > rules.TitleMaxWords {
+		add("title", "title_max_words", rules.TitleMaxWords)
+	}
+	titleChars := utf8.RuneCountInString(title)
+	if rules.TitleMinChars > 0 && titleChars < rules.TitleMinChars {
+		add("title", "title_min_chars", rules.TitleMinChars)
+	}
+	if rules.TitleMaxChars > 0 && titleChars > rules.TitleMaxChars {
+		add("title", "title_max_chars", rules.TitleMaxChars)
+	}
+
+	if rules.ExcerptMaxWords > 0 && len(strings.Fields(article.Excerpt)) > rules.ExcerptMaxWords {
+		add("excerpt", "excerpt_max_words", rules.ExcerptMaxWords)
+	}
+
+	if rules.ContentMinWords > 0 && models.CountWords(article.Content) < rules.ContentMinWords {
+		add("content", "content_min_words", rules.ContentMinWords)
+	}
+	if rules.MaxLinks > 0 && countLinks(article.Content) > rules.MaxLinks {
+		add("content", "max_links", rules.MaxLinks)
+	}
+
+	if rules.MinTags > 0 && article.TagCount < rules.MinTags {
+		add("tag_ids", "min_tags", rules.MinTags)
+	}
+
+	if len(rules.BannedWords) > 0 {
+		fields := []struct{ name, text string }{
+			{"title", title},
+			{"excerpt", article.Excerpt},
+			{"content", html.UnescapeString(models.PlainText(article.Content))},
+		}
+		for _, field := range fields {
+			if found := bannedWords(field.text, rules.BannedWords); len(found) > 0 {
+				add(field.name, "banned_words", strings.Join(found, ", "))
+			}
+		}
+	}
+
+	if article.Thumbnail != "" && (rules.ThumbnailMinWidth > 0 || rules.ThumbnailMinHeight > 0) {
+		width, height, err := thumbnailSize(article.Thumbnail)
+		// A thumbnail that cannot be measured cannot meet the rule
+		switch {
+		case err == errUntrustedHost:
+			// Only our own storage is downloaded from
+			add("thumbnail", "thumbnail_untrusted_host")
+		case err == image.ErrFormat:
+			// Formats such as WebP and HEIC cannot be measured here
+			add("thumbnail", "thumbnail_format")
+		case err != nil:
+			add("thumbnail", "thumbnail_unreachable")
+		case width < rules.ThumbnailMinWidth || height < rules.ThumbnailMinHeight:
+			add("thumbnail", "thumbnail_dimensions",
+				rules.ThumbnailMinWidth, rules.ThumbnailMinHeight, width, height)
+		}
+	}
+
+	return violations
+}
+
+// countLinks counts the links with a target in an HTML fragment
+func countLinks(content string) int {
+	count := 0
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return count
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			if string(name) != "a" {
+				continue
+			}
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				if string(key) == "href" && strings.TrimSpace(string(value)) != "" {
+					count++
+					break
+				}
+			}
+		}
+	}
+}
+
+// bannedWords returns the banned words or phrases found in text, matching
+// whole words case-insensitively
+func bannedWords(text string, banned []string) []string {
+	normalized := " " + normalizeWords(text) + " "
+	var found []string
+	for _, word := range banned {
+		phrase := normalizeWords(word)
+		if phrase != "" && strings.Contains(normalized, " "+phrase+" ") {
+			found = append(found, word)
+		}
+	}
+	return found
+}
+
+func normalizeWords(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}), " ")
+}
+
+var (
+	thumbnailClient  = &http.Client{Timeout: 10 * time.Second}
+	errUntrustedHost = errors.New("host is not an allowed image host")
+)
+
+// thumbnailSize downloads the start of an image and reads its dimensions.
+// Only images on the hosts allowed by the content policy are downloaded.
+func thumbnailSize(url string) (int, int, error) {
+	if !sanitize.ImageAllowed(url) {
+		return 0, 0, errUntrustedHost
+	}
+	resp, err := thumbnailClient.Get(url)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	config, _, err := image.DecodeConfig(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return 0, 0, err
+	}
+	return config.Width, config.Height, nil
+}
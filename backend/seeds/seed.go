@@ -24,9 +24,10 @@ func seedData() {
 	// Create admin user
 	hashedPassword, _ := services.HashPassword("admin123")
 	admin := models.User{
-		Username:     "admin",
-		Email:        "admin@xinxun.us",
-		PasswordHash: hashedPassword,
+		Username:           "admin",
+		Email:              "admin@xinxun.us",
+		PasswordHash:       hashedPassword,
+		MustChangePassword: true,
 	}
 
 	if err := database.DB.FirstOrCreate(&admin, models.User{Username: "admin"}).Error; err != nil {
@@ -143,4 +144,3 @@ func seedData() {
 		}
 	}
 }
-
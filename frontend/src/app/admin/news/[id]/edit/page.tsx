@@ -75,12 +75,12 @@ export default function EditNewsPage() {
   const loadData = async () => {
     try {
       const [newsResponse, categoriesResponse, tagsResponse] = await Promise.all([
-        adminApi.getAllNews({ page: 1, limit: 1000 }),
+        adminApi.getNewsById(parseInt(params.id as string)),
         adminCategoryApi.getAll(),
         tagApi.getAll(),
       ])
 
-      const foundNews = newsResponse.data.find((n) => n.id === parseInt(params.id as string))
+      const foundNews = newsResponse.data
       if (!foundNews) {
         toast.error('Artikel tidak ditemukan')
         router.push('/admin/dashboard')
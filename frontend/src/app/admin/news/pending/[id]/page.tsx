@@ -33,8 +33,8 @@ export default function PendingNewsDetailPage() {
 
   const loadNews = async () => {
     try {
-      const response = await adminApi.getAllNews({ page: 1, limit: 1000 })
-      const foundNews = response.data.find((n) => n.id === parseInt(params.id as string))
+      const response = await adminApi.getNewsById(parseInt(params.id as string))
+      const foundNews = response.data
       if (!foundNews || foundNews.status !== 'pending') {
         toast.error('Artikel tidak ditemukan atau bukan pending')
         router.push('/admin/news/pending')
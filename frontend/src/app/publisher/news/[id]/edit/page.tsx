@@ -68,15 +68,13 @@ export default function EditPublisherNewsPage() {
   const loadData = async () => {
     try {
       const [newsResponse, categoriesResponse] = await Promise.all([
-        newsApi.getAll({ page: 1, limit: 1000 }),
+        publisherApi.getNewsById(parseInt(params.id as string)),
         categoryApi.getAll(),
       ])
 
       const user = JSON.parse(localStorage.getItem('publisher_user') || '{}')
-      const foundNews = newsResponse.data.find(
-        (n) => n.id === parseInt(params.id as string) && n.author_id === user.id
-      )
-      if (!foundNews) {
+      const foundNews = newsResponse.data
+      if (!foundNews || foundNews.author_id !== user.id) {
         toast.error('Artikel tidak ditemukan atau bukan milik Anda')
         router.push('/publisher/dashboard')
         return
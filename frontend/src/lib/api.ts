@@ -223,6 +223,12 @@ export const adminApi = {
     return response.data
   },
 
+  getNewsById: async (id: number): Promise<SingleNewsResponse> => {
+    const apiInstance = getApi()
+    const response = await apiInstance.get(`/admin/news/${id}`)
+    return response.data
+  },
+
   createNews: async (data: any) => {
     const apiInstance = getApi()
     const response = await apiInstance.post('/admin/news', data)
@@ -338,6 +344,12 @@ export const publisherApi = {
     return response.data
   },
 
+  getNewsById: async (id: number): Promise<SingleNewsResponse> => {
+    const apiInstance = getApi()
+    const response = await apiInstance.get(`/publisher/news/${id}`)
+    return response.data
+  },
+
   createNews: async (data: any) => {
     const apiInstance = getApi()
     const response = await apiInstance.post('/publisher/news', data)
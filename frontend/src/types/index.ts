@@ -57,6 +57,7 @@ export interface NewsResponse {
     page: number
     limit: number
     pages: number
+    next_cursor?: string | null
   }
 }
 